@@ -0,0 +1,61 @@
+package apperror
+
+import "errors"
+
+// Code is the machine-readable error code returned to API clients.
+type Code string
+
+const (
+	CodeNotFound          Code = "NOT_FOUND"
+	CodeConflict          Code = "CONFLICT"
+	CodeForbidden         Code = "FORBIDDEN"
+	CodeUnauthorized      Code = "UNAUTHORIZED"
+	CodeValidation        Code = "VALIDATION_FAILED"
+	CodeInvalidTransition Code = "INVALID_STATE_TRANSITION"
+	CodeInternal          Code = "INTERNAL"
+)
+
+// Error is a domain error tagged with a Code. Services wrap one of the
+// sentinels below with %w, so the code survives any added context.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+var (
+	ErrNotFound          = New(CodeNotFound, "not found")
+	ErrConflict          = New(CodeConflict, "already exists")
+	ErrForbidden         = New(CodeForbidden, "forbidden")
+	ErrUnauthorized      = New(CodeUnauthorized, "unauthorized")
+	ErrValidation        = New(CodeValidation, "validation failed")
+	ErrInvalidTransition = New(CodeInvalidTransition, "invalid state transition")
+)
+
+func New(code Code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches any error with the same code, so a more specific error such as
+// an invalid file type still counts as a validation failure.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// CodeOf returns the code of the first Error in err's chain, or CodeInternal
+// when err carries none.
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+
+	return CodeInternal
+}
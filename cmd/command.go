@@ -1,19 +1,52 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
 
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/dto"
 	"github.com/Amierza/mc-kalak-backend/migrations"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-func Command(db *gorm.DB) {
+func Command(db *gorm.DB, appLogger *zap.Logger) {
 	migrate := false
 	seed := false
 	rollback := false
+	recomputeStats := false
+	dryRun := false
+	migrateUp := -1
+	migrateDown := 0
+	migrateStatus := false
+	export := ""
+	exportFormat := constants.ENUM_EXPORT_FORMAT_CSV
+	exportOutput := ""
+	importFile := ""
+	backupFile := ""
+	restoreFile := ""
+
+	args := os.Args[1:]
+	for i, arg := range args {
+		if arg == "--migrate-up" {
+			migrateUp = stepsArg(args, i, 0)
+		}
+
+		if arg == "--migrate-down" {
+			migrateDown = stepsArg(args, i, 1)
+		}
+
+		if arg == "--migrate-status" {
+			migrateStatus = true
+		}
 
-	for _, arg := range os.Args[1:] {
 		if arg == "--migrate" {
 			migrate = true
 		}
@@ -25,6 +58,89 @@ func Command(db *gorm.DB) {
 		if arg == "--rollback" {
 			rollback = true
 		}
+
+		if arg == "--recompute-stats" {
+			recomputeStats = true
+		}
+
+		if arg == "--dry-run" {
+			dryRun = true
+		}
+
+		if arg == "--export" && i+1 < len(args) {
+			export = args[i+1]
+		}
+
+		if arg == "--format" && i+1 < len(args) {
+			exportFormat = args[i+1]
+		}
+
+		if arg == "--output" && i+1 < len(args) {
+			exportOutput = args[i+1]
+		}
+
+		if arg == "--import" && i+1 < len(args) {
+			importFile = args[i+1]
+		}
+
+		if arg == "--backup" && i+1 < len(args) {
+			backupFile = args[i+1]
+		}
+
+		if arg == "--restore" && i+1 < len(args) {
+			restoreFile = args[i+1]
+		}
+	}
+
+	if migrateUp >= 0 {
+		applied, err := migrations.MigrateUp(db, migrateUp)
+		for _, name := range applied {
+			log.Printf("migrated up %s", name)
+		}
+		if err != nil {
+			log.Fatalf("error migrate up: %v", err)
+		}
+
+		log.Printf("migrate up complete successfully: %d applied", len(applied))
+	}
+
+	if migrateDown > 0 {
+		reverted, err := migrations.MigrateDown(db, migrateDown)
+		for _, name := range reverted {
+			log.Printf("migrated down %s", name)
+		}
+		if err != nil {
+			log.Fatalf("error migrate down: %v", err)
+		}
+
+		log.Printf("migrate down complete successfully: %d reverted", len(reverted))
+	}
+
+	if migrateStatus {
+		statuses, err := migrations.GetMigrationStatus(db)
+		if err != nil {
+			log.Fatalf("error migrate status: %v", err)
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			log.Printf("%06d_%s\t%s", status.Version, status.Name, appliedAt)
+		}
+	}
+
+	if restoreFile != "" {
+		manifest, err := migrations.Restore(db, restoreFile)
+		if err != nil {
+			log.Fatalf("error restore: %v", err)
+		}
+
+		for _, table := range manifest.Tables {
+			log.Printf("restored %s: %d rows", table.Name, table.Rows)
+		}
+		log.Printf("restore complete successfully: %d files (backup from %s)", len(manifest.Files), manifest.CreatedAt.Format("2006-01-02 15:04:05"))
 	}
 
 	if migrate {
@@ -36,7 +152,7 @@ func Command(db *gorm.DB) {
 	}
 
 	if seed {
-		if err := migrations.Seed(db); err != nil {
+		if err := migrations.Seed(db, appLogger); err != nil {
 			log.Printf("error migration seeder: %v", err)
 		}
 
@@ -50,4 +166,124 @@ func Command(db *gorm.DB) {
 
 		log.Println("rollback complete successfully")
 	}
+
+	if export != "" {
+		if err := runExport(db, appLogger, export, exportFormat, exportOutput); err != nil {
+			log.Fatalf("error export: %v", err)
+		}
+
+		if exportOutput != "" {
+			log.Printf("export %s complete successfully: %s", export, exportOutput)
+		}
+	}
+
+	if importFile != "" {
+		result, err := runImport(db, appLogger, importFile, dryRun)
+		if err != nil {
+			log.Fatalf("error import: %v", err)
+		}
+
+		for _, row := range result.Rows {
+			if row.Error != "" {
+				log.Printf("row %d %s: %s", row.Row, row.Status, row.Error)
+			}
+		}
+
+		if dryRun {
+			log.Printf("import dry run: %d of %d rows valid", result.Valid, result.TotalRows)
+		} else {
+			log.Printf("import complete successfully: %d of %d rows imported", result.Imported, result.TotalRows)
+		}
+	}
+
+	if backupFile != "" {
+		manifest, err := migrations.Backup(db, backupFile)
+		if err != nil {
+			log.Fatalf("error backup: %v", err)
+		}
+
+		for _, table := range manifest.Tables {
+			log.Printf("backed up %s: %d rows", table.Name, table.Rows)
+		}
+		for _, name := range manifest.MissingFiles {
+			log.Printf("upload %s is referenced but missing, skipped", name)
+		}
+		log.Printf("backup complete successfully: %s (%d files)", backupFile, len(manifest.Files))
+	}
+
+	if recomputeStats {
+		statService := service.NewStatService(db, repository.NewPlayerStatRepository(db), repository.NewScoringRuleRepository(db), repository.NewClaimRepository(db), repository.NewRatingHistoryRepository(db), repository.NewUserRepository(db), repository.NewVoterStatRepository(db), appLogger)
+		result, err := statService.RecomputeAll(context.Background(), dryRun)
+		if err != nil {
+			log.Fatalf("error recompute stats: %v", err)
+		}
+
+		for _, diff := range result.Diffs {
+			for _, change := range diff.Changes {
+				log.Printf("%s (%s) %s: %s -> %s", diff.Player.Username, diff.Player.ID, change.Field, change.Old, change.New)
+			}
+		}
+
+		if dryRun {
+			log.Printf("recompute stats dry run: %d of %d players would change (scoring version %d)", result.ChangedPlayers, result.TotalPlayers, result.ScoringVersion)
+		} else {
+			log.Printf("recompute stats complete successfully: %d of %d players changed (scoring version %d)", result.ChangedPlayers, result.TotalPlayers, result.ScoringVersion)
+		}
+	}
+}
+
+// runExport streams claims or stats to the output file, or stdout when none is
+// given, so the CLI export can be piped straight into other tools.
+func runExport(db *gorm.DB, appLogger *zap.Logger, target string, format string, output string) error {
+	if format != constants.ENUM_EXPORT_FORMAT_CSV && format != constants.ENUM_EXPORT_FORMAT_NDJSON {
+		return fmt.Errorf("unknown export format %q, expected csv or ndjson", format)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	exportService := service.NewExportService(repository.NewExportRepository(db), appLogger)
+	switch target {
+	case "claims":
+		return exportService.ExportClaims(context.Background(), &dto.ExportClaimsRequest{Format: format}, w)
+	case "stats":
+		return exportService.ExportStats(context.Background(), &dto.ExportStatsRequest{Format: format}, w)
+	default:
+		return fmt.Errorf("unknown export target %q, expected claims or stats", target)
+	}
+}
+
+func runImport(db *gorm.DB, appLogger *zap.Logger, path string, dryRun bool) (*dto.ImportClaimsResponse, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	importService := service.NewImportService(db, repository.NewClaimRepository(db), repository.NewUserRepository(db), repository.NewClaimRevisionRepository(db), repository.NewPlayerStatRepository(db), repository.NewScoringRuleRepository(db), repository.NewRatingHistoryRepository(db), repository.NewVoteRepository(db), repository.NewAchievementRepository(db), repository.NewNotificationRepository(db), appLogger)
+	return importService.ImportClaims(context.Background(), &dto.ImportClaimsRequest{DryRun: dryRun}, file)
+}
+
+// stepsArg reads the optional step count following the flag at index i.
+func stepsArg(args []string, i int, fallback int) int {
+	if i+1 >= len(args) {
+		return fallback
+	}
+
+	steps, err := strconv.Atoi(args[i+1])
+	if err != nil {
+		return fallback
+	}
+	if steps < 0 {
+		log.Fatalf("error invalid number of steps: %d", steps)
+	}
+
+	return steps
 }
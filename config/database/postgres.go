@@ -2,22 +2,18 @@ package database
 
 import (
 	"fmt"
-	"log"
 	"os"
 
-	"github.com/Amierza/mc-kalak-backend/constants"
-	"github.com/joho/godotenv"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func SetUpPostgreSQLConnection() *gorm.DB {
-	if os.Getenv("APP_ENV") != constants.ENUM_RUN_PRODUCTION {
-		if err := godotenv.Load(".env"); err != nil {
-			panic(fmt.Errorf("failed to laod .env file: %v", err))
-		}
-	}
-
+// SetUpPostgreSQLConnection opens the database configured through the DB_*
+// variables. Only the host and database name are logged, never the DSN, since
+// it carries the password.
+func SetUpPostgreSQLConnection(log *zap.Logger) *gorm.DB {
 	dbHost := os.Getenv("DB_HOST")
 	dbUser := os.Getenv("DB_USER")
 	dbPass := os.Getenv("DB_PASS")
@@ -25,26 +21,28 @@ func SetUpPostgreSQLConnection() *gorm.DB {
 	dbPort := os.Getenv("DB_PORT")
 
 	dsn := fmt.Sprintf("host=%v, user=%v password=%v dbname=%v port=%v TimeZone=Asia/Jakarta", dbHost, dbUser, dbPass, dbName, dbPort)
-	log.Printf("connecting to postgres: %v", dsn)
+	log.Info("connecting to postgres", zap.String("host", dbHost), zap.String("database", dbName))
 
 	db, err := gorm.Open(postgres.New(postgres.Config{
 		DSN:                  dsn,
 		PreferSimpleProtocol: true,
-	}), &gorm.Config{})
+	}), &gorm.Config{
+		Logger: logger.NewGormLogger(log),
+	})
 	if err != nil {
 		panic(fmt.Errorf("failed to connect postgres: %v", err))
 	}
 
-	log.Println("postgres connection established")
+	log.Info("postgres connection established")
 	return db
 }
 
-func ClosePostgreSQLConnection(db *gorm.DB) {
+func ClosePostgreSQLConnection(db *gorm.DB, log *zap.Logger) {
 	dbSQL, err := db.DB()
 	if err != nil {
 		panic(fmt.Errorf("error closing postgres connection: %v", err))
 	}
 
 	dbSQL.Close()
-	log.Println("postgres connection closed")
+	log.Info("postgres connection closed")
 }
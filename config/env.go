@@ -0,0 +1,19 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/joho/godotenv"
+)
+
+// LoadEnv reads .env outside production, where the host sets the environment.
+// It runs before anything that reads configuration, the logger included.
+func LoadEnv() {
+	if os.Getenv("APP_ENV") != constants.ENUM_RUN_PRODUCTION {
+		if err := godotenv.Load(".env"); err != nil {
+			panic(fmt.Errorf("failed to laod .env file: %v", err))
+		}
+	}
+}
@@ -7,6 +7,9 @@ const (
 	ENUM_RUN_PRODUCTION = "production"
 	ENUM_RUN_TESTING    = "testing"
 
+	ENUM_EXPORT_FORMAT_CSV    = "csv"
+	ENUM_EXPORT_FORMAT_NDJSON = "ndjson"
+
 	ENUM_PAGINATION_LIMIT = 10
 	ENUM_PAGINATION_PAGE  = 1
 )
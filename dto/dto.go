@@ -2,7 +2,9 @@ package dto
 
 import (
 	"errors"
+	"time"
 
+	"github.com/Amierza/mc-kalak-backend/apperror"
 	"github.com/Amierza/mc-kalak-backend/entity"
 	"github.com/Amierza/mc-kalak-backend/response"
 	"github.com/google/uuid"
@@ -65,19 +67,24 @@ var (
 	ErrTokenInvalid            = errors.New("token invalid")
 	ErrValidateToken           = errors.New("failed to validate token")
 
+	// Auth
+	ErrInvalidCredentials = apperror.New(apperror.CodeUnauthorized, "invalid credentials")
+
 	// File
-	ErrNoFilesUploaded    = errors.New("failed no files uploaded")
-	ErrInvalidFileType    = errors.New("invalid file type")
+	ErrNoFilesUploaded    = apperror.New(apperror.CodeValidation, "failed no files uploaded")
+	ErrInvalidFileType    = apperror.New(apperror.CodeValidation, "invalid file type")
 	ErrSaveFile           = errors.New("failed save file")
 	ErrCreateFolderAssets = errors.New("failed create folder assets")
 	ErrDeleteOldImage     = errors.New("failed to delete old image")
 
 	// General
-	ErrNotFound         = errors.New("not found")
-	ErrValidationFailed = errors.New("validation failed")
-	ErrAlreadyExists    = errors.New("already exists")
-	ErrInternal         = errors.New("error internal")
-	ErrUnauthorized     = errors.New("unauthorized")
+	ErrNotFound          = apperror.ErrNotFound
+	ErrValidationFailed  = apperror.ErrValidation
+	ErrAlreadyExists     = apperror.ErrConflict
+	ErrInternal          = errors.New("error internal")
+	ErrUnauthorized      = apperror.ErrUnauthorized
+	ErrForbidden         = apperror.ErrForbidden
+	ErrInvalidTransition = apperror.ErrInvalidTransition
 
 	// Input
 
@@ -107,11 +114,14 @@ type (
 // User
 type (
 	UserResponse struct {
-		ID        uuid.UUID `json:"id"`
-		Username  string    `json:"username"`
-		Password  string    `json:"password"`
-		AvatarURL string    `json:"avatar_url"`
-		IsActive  bool      `json:"is_active"`
+		ID        uuid.UUID           `json:"id"`
+		Username  string              `json:"username"`
+		Password  string              `json:"password"`
+		AvatarURL string              `json:"avatar_url"`
+		IsActive  bool                `json:"is_active"`
+		Role      string              `json:"role"`
+		Badges    []BadgeResponse     `json:"badges,omitempty"`
+		Stats     *PlayerStatResponse `json:"stats,omitempty"`
 		TimestampTemplate
 	}
 	UpdateProfileRequest struct {
@@ -132,19 +142,21 @@ type (
 		TotalPlayer     int               `binding:"required,min=2,max=8" json:"total_player"`
 		ScreenshotURL   string            `binding:"required" json:"screenshot_url"`
 		ClaimedPlayerID uuid.UUID         `binding:"required" json:"claimed_player_id"`
-		ReporterID      uuid.UUID         `binding:"required" json:"reporter_id"`
 	}
 	ClaimResponse struct {
-		ID            uuid.UUID          `json:"id"`
-		Event         entity.ClaimEvent  `json:"event"`
-		Status        entity.ClaimStatus `json:"status"`
-		MatchDate     string             `json:"match_date"`
-		TotalPlayer   int                `json:"total_player"`
-		ScreenshotURL string             `json:"screenshot_url"`
-		ApproveCount  int                `json:"approve_count"`
-		RejectCount   int                `json:"reject_count"`
-		ClaimedPlayer UserSimpleResponse `json:"claimed_player"`
-		Reporter      UserSimpleResponse `json:"reporter"`
+		ID            uuid.UUID               `json:"id"`
+		Event         entity.ClaimEvent       `json:"event"`
+		Status        entity.ClaimStatus      `json:"status"`
+		MatchDate     string                  `json:"match_date"`
+		TotalPlayer   int                     `json:"total_player"`
+		ScreenshotURL string                  `json:"screenshot_url"`
+		ApproveCount  int                     `json:"approve_count"`
+		RejectCount   int                     `json:"reject_count"`
+		IsImported    bool                    `json:"is_imported"`
+		CommentCount  int                     `json:"comment_count"`
+		Reactions     []ReactionCountResponse `json:"reactions"`
+		ClaimedPlayer UserSimpleResponse      `json:"claimed_player"`
+		Reporter      UserSimpleResponse      `json:"reporter"`
 		TimestampTemplate
 	}
 	UpdateClaimRequest struct {
@@ -154,17 +166,29 @@ type (
 		TotalPlayer     int               `binding:"required,min=2,max=8" json:"total_player"`
 		ScreenshotURL   string            `binding:"required" json:"screenshot_url"`
 		ClaimedPlayerID uuid.UUID         `binding:"required" json:"claimed_player_id"`
-		ReporterID      uuid.UUID         `binding:"required" json:"reporter_id"`
 	}
 	ClaimVoteRequest struct {
 		ID   uuid.UUID `json:"-"`
 		Type string    `binding:"required,oneof=APPROVE REJECT" json:"type"`
 	}
+	ResolveClaimRequest struct {
+		ID     uuid.UUID          `json:"-"`
+		Status entity.ClaimStatus `binding:"required,oneof=FINAL_APPROVED FINAL_REJECTED" json:"status"`
+		Reason string             `binding:"required,min=5" json:"reason"`
+	}
 	ClaimVoteResponse struct {
 		ID    uuid.UUID          `json:"id"`
 		Voter UserSimpleResponse `json:"voter"`
 		Type  entity.VoteType    `json:"type"`
 	}
+	ClaimFilterRequest struct {
+		Search   string `form:"search"`
+		Status   string `form:"status" binding:"omitempty,oneof=PENDING FINAL_APPROVED FINAL_REJECTED DISPUTED CANCELLED EXPIRED"`
+		Event    string `form:"event" binding:"omitempty,oneof=KING KONG NGOK"`
+		PlayerID string `form:"player_id" binding:"omitempty,uuid"`
+		From     string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+		To       string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	}
 	ClaimPaginationResponse struct {
 		response.PaginationResponse
 		Data []*ClaimResponse `json:"data"`
@@ -173,4 +197,351 @@ type (
 		response.PaginationResponse
 		Claims []*entity.Claim
 	}
+	ClaimFieldChange struct {
+		Field string `json:"field"`
+		Old   string `json:"old"`
+		New   string `json:"new"`
+	}
+	ClaimRevisionResponse struct {
+		ID        uuid.UUID             `json:"id"`
+		Action    entity.RevisionAction `json:"action"`
+		Actor     UserSimpleResponse    `json:"actor"`
+		Changes   []ClaimFieldChange    `json:"changes"`
+		Reason    string                `json:"reason,omitempty"`
+		CreatedAt string                `json:"created_at"`
+	}
+)
+
+// Notification
+type (
+	NotificationResponse struct {
+		ID        uuid.UUID               `json:"id"`
+		Type      entity.NotificationType `json:"type"`
+		Message   string                  `json:"message"`
+		IsRead    bool                    `json:"is_read"`
+		ClaimID   *uuid.UUID              `json:"claim_id"`
+		CreatedAt string                  `json:"created_at"`
+	}
+)
+
+// Dispute
+type (
+	CreateDisputeRequest struct {
+		ClaimID uuid.UUID `json:"-"`
+		Reason  string    `binding:"required,min=5" json:"reason"`
+	}
+	DisputeVoteRequest struct {
+		ClaimID   uuid.UUID `json:"-"`
+		DisputeID uuid.UUID `json:"-"`
+		Type      string    `binding:"required,oneof=APPROVE REJECT" json:"type"`
+	}
+	DisputeResponse struct {
+		ID             uuid.UUID            `json:"id"`
+		ClaimID        uuid.UUID            `json:"claim_id"`
+		Reason         string               `json:"reason"`
+		Status         entity.DisputeStatus `json:"status"`
+		PreviousStatus entity.ClaimStatus   `json:"previous_status"`
+		UpholdCount    int                  `json:"uphold_count"`
+		DismissCount   int                  `json:"dismiss_count"`
+		Appellant      UserSimpleResponse   `json:"appellant"`
+		ResolvedAt     *string              `json:"resolved_at"`
+		TimestampTemplate
+	}
+)
+
+// Claim Comment
+type (
+	CreateClaimCommentRequest struct {
+		ClaimID  uuid.UUID  `json:"-"`
+		ParentID *uuid.UUID `json:"parent_id"`
+		Content  string     `binding:"required,max=1000" json:"content"`
+	}
+	UpdateClaimCommentRequest struct {
+		ID      uuid.UUID `json:"-"`
+		ClaimID uuid.UUID `json:"-"`
+		Content string    `binding:"required,max=1000" json:"content"`
+	}
+	ClaimCommentResponse struct {
+		ID       uuid.UUID               `json:"id"`
+		ClaimID  uuid.UUID               `json:"claim_id"`
+		ParentID *uuid.UUID              `json:"parent_id"`
+		Content  string                  `json:"content"`
+		Author   UserSimpleResponse      `json:"author"`
+		Replies  []*ClaimCommentResponse `json:"replies"`
+		TimestampTemplate
+	}
+)
+
+// Reaction
+type (
+	ReactionRequest struct {
+		ClaimID uuid.UUID `json:"-"`
+		Emoji   string    `binding:"required,max=32" json:"emoji"`
+	}
+	ReactionCountResponse struct {
+		Emoji string `json:"emoji"`
+		Count int    `json:"count"`
+	}
+)
+
+// Scoring Rule
+type (
+	UpdateScoringRuleRequest struct {
+		KingPoints         int  `binding:"min=-100,max=100" json:"king_points"`
+		KongPoints         int  `binding:"min=-100,max=100" json:"kong_points"`
+		NgokPoints         int  `binding:"min=-100,max=100" json:"ngok_points"`
+		ScaleByTotalPlayer bool `json:"scale_by_total_player"`
+		BaselinePlayers    int  `binding:"required,min=2,max=8" json:"baseline_players"`
+	}
+	ScoringRuleResponse struct {
+		ID                 uuid.UUID           `json:"id"`
+		Version            int                 `json:"version"`
+		KingPoints         int                 `json:"king_points"`
+		KongPoints         int                 `json:"kong_points"`
+		NgokPoints         int                 `json:"ngok_points"`
+		ScaleByTotalPlayer bool                `json:"scale_by_total_player"`
+		BaselinePlayers    int                 `json:"baseline_players"`
+		CreatedBy          *UserSimpleResponse `json:"created_by"`
+		RecomputedPlayers  int                 `json:"recomputed_players,omitempty"`
+		TimestampTemplate
+	}
+)
+
+// Stat
+type (
+	RecomputeStatsRequest struct {
+		DryRun bool `form:"dry_run"`
+	}
+	LeaderboardRequest struct {
+		Sort  string `form:"sort" binding:"omitempty,oneof=score rating win_rate total_match king_count kong_count ngok_count current_king_streak longest_king_streak current_kong_streak longest_kong_streak current_ngok_streak longest_ngok_streak"`
+		Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	}
+	StreakResponse struct {
+		Current int `json:"current"`
+		Longest int `json:"longest"`
+	}
+	PlayerStreaksResponse struct {
+		King StreakResponse `json:"king"`
+		Kong StreakResponse `json:"kong"`
+		Ngok StreakResponse `json:"ngok"`
+	}
+	PlayerStatResponse struct {
+		Player     UserSimpleResponse    `json:"player"`
+		TotalMatch int                   `json:"total_match"`
+		KingCount  int                   `json:"king_count"`
+		KongCount  int                   `json:"kong_count"`
+		NgokCount  int                   `json:"ngok_count"`
+		Score      int                   `json:"score"`
+		WinRate    float64               `json:"win_rate"`
+		Rating     float64               `json:"rating"`
+		Streaks    PlayerStreaksResponse `json:"streaks"`
+	}
+	HeadToHeadRequest struct {
+		PlayerAID string `form:"a" binding:"required,uuid"`
+		PlayerBID string `form:"b" binding:"required,uuid"`
+	}
+	HeadToHeadSideResponse struct {
+		Player    UserSimpleResponse `json:"player"`
+		KingCount int                `json:"king_count"`
+		KongCount int                `json:"kong_count"`
+		NgokCount int                `json:"ngok_count"`
+	}
+	HeadToHeadMatchResponse struct {
+		MatchDate string              `json:"match_date"`
+		PlayerA   []entity.ClaimEvent `json:"player_a"`
+		PlayerB   []entity.ClaimEvent `json:"player_b"`
+	}
+	HeadToHeadResponse struct {
+		SharedMatches int                       `json:"shared_matches"`
+		PlayerA       HeadToHeadSideResponse    `json:"player_a"`
+		PlayerB       HeadToHeadSideResponse    `json:"player_b"`
+		MoreKings     *uuid.UUID                `json:"more_kings"`
+		MoreNgoks     *uuid.UUID                `json:"more_ngoks"`
+		Recent        []HeadToHeadMatchResponse `json:"recent"`
+	}
+	LeaderboardEntryResponse struct {
+		Rank int `json:"rank"`
+		PlayerStatResponse
+	}
+	LeaderboardResponse struct {
+		Sort    string                      `json:"sort"`
+		Entries []*LeaderboardEntryResponse `json:"entries"`
+	}
+	StatFieldChange struct {
+		Field string `json:"field"`
+		Old   string `json:"old"`
+		New   string `json:"new"`
+	}
+	PlayerStatDiffResponse struct {
+		Player  UserSimpleResponse `json:"player"`
+		Changes []StatFieldChange  `json:"changes"`
+	}
+	RecomputeStatsResponse struct {
+		DryRun         bool                      `json:"dry_run"`
+		ScoringVersion int                       `json:"scoring_version"`
+		TotalPlayers   int                       `json:"total_players"`
+		ChangedPlayers int                       `json:"changed_players"`
+		Diffs          []*PlayerStatDiffResponse `json:"diffs"`
+	}
+)
+
+// Rating
+type (
+	RatingHistoryEntryResponse struct {
+		MatchDate    string  `json:"match_date"`
+		RatingBefore float64 `json:"rating_before"`
+		RatingAfter  float64 `json:"rating_after"`
+		Delta        float64 `json:"delta"`
+		Opponents    int     `json:"opponents"`
+	}
+	RatingHistoryResponse struct {
+		Player        UserSimpleResponse           `json:"player"`
+		CurrentRating float64                      `json:"current_rating"`
+		History       []RatingHistoryEntryResponse `json:"history"`
+	}
+)
+
+// Achievement
+type (
+	BadgeResponse struct {
+		Code        string     `json:"code"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		Icon        string     `json:"icon"`
+		ClaimID     *uuid.UUID `json:"claim_id"`
+		AwardedAt   string     `json:"awarded_at"`
+	}
+)
+
+// Profile Stats
+type (
+	MonthlyStatResponse struct {
+		Month      string  `json:"month"`
+		TotalMatch int     `json:"total_match"`
+		KingCount  int     `json:"king_count"`
+		KongCount  int     `json:"kong_count"`
+		NgokCount  int     `json:"ngok_count"`
+		WinRate    float64 `json:"win_rate"`
+	}
+	WeekdayStatResponse struct {
+		DayOfWeek  int     `json:"-"`
+		Day        string  `json:"day"`
+		TotalMatch int     `json:"total_match"`
+		KingCount  int     `json:"king_count"`
+		NgokCount  int     `json:"ngok_count"`
+		WinRate    float64 `json:"win_rate"`
+	}
+	ReportedClaimStatResponse struct {
+		Total        int     `json:"total"`
+		Approved     int     `json:"approved"`
+		Rejected     int     `json:"rejected"`
+		Pending      int     `json:"pending"`
+		ApprovalRate float64 `json:"approval_rate"`
+	}
+	VoteStatResponse struct {
+		VotesCast     int     `json:"votes_cast"`
+		Decided       int     `json:"decided"`
+		Agreed        int     `json:"agreed"`
+		AgreementRate float64 `json:"agreement_rate"`
+	}
+	ProfileStatsResponse struct {
+		Monthly        []MonthlyStatResponse     `json:"monthly"`
+		Weekdays       []WeekdayStatResponse     `json:"weekdays"`
+		BestDay        *string                   `json:"best_day"`
+		WorstDay       *string                   `json:"worst_day"`
+		ReportedClaims ReportedClaimStatResponse `json:"reported_claims"`
+		Votes          VoteStatResponse          `json:"votes"`
+	}
+)
+
+// Voter Stat
+type (
+	VoterStatRepositoryResponse struct {
+		VoterID        uuid.UUID
+		Username       string
+		AvatarURL      string
+		VotesCast      int
+		Decided        int
+		Agreed         int
+		RejectCount    int
+		AvgVoteSeconds float64
+		EligibleClaims int
+	}
+	VoterStatResponse struct {
+		Voter             UserSimpleResponse `json:"voter"`
+		VotesCast         int                `json:"votes_cast"`
+		Decided           int                `json:"decided"`
+		Agreed            int                `json:"agreed"`
+		AgreementRate     float64            `json:"agreement_rate"`
+		RejectRate        float64            `json:"reject_rate"`
+		AvgVoteSeconds    float64            `json:"avg_vote_seconds"`
+		ParticipationRate float64            `json:"participation_rate"`
+		Reputation        float64            `json:"reputation"`
+		VoteWeight        float64            `json:"vote_weight"`
+	}
+)
+
+// Export
+type (
+	ExportClaimsRequest struct {
+		Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+		ClaimFilterRequest
+	}
+	ExportStatsRequest struct {
+		Format string `form:"format" binding:"omitempty,oneof=csv ndjson"`
+	}
+	ClaimExportRow struct {
+		ID            uuid.UUID `json:"id"`
+		MatchDate     string    `json:"match_date"`
+		Event         string    `json:"event"`
+		Status        string    `json:"status"`
+		TotalPlayer   int       `json:"total_player"`
+		ClaimedPlayer string    `json:"claimed_player"`
+		Reporter      string    `json:"reporter"`
+		ApproveCount  int       `json:"approve_count"`
+		RejectCount   int       `json:"reject_count"`
+		ApprovedBy    string    `json:"approved_by"`
+		RejectedBy    string    `json:"rejected_by"`
+		IsImported    bool      `json:"is_imported"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+	PlayerStatExportRow struct {
+		PlayerID          uuid.UUID `json:"player_id"`
+		Username          string    `json:"username"`
+		TotalMatch        int       `json:"total_match"`
+		KingCount         int       `json:"king_count"`
+		KongCount         int       `json:"kong_count"`
+		NgokCount         int       `json:"ngok_count"`
+		Score             int       `json:"score"`
+		ScoringVersion    int       `json:"scoring_version"`
+		WinRate           float64   `json:"win_rate"`
+		Rating            float64   `json:"rating"`
+		CurrentKingStreak int       `json:"current_king_streak"`
+		LongestKingStreak int       `json:"longest_king_streak"`
+		CurrentKongStreak int       `json:"current_kong_streak"`
+		LongestKongStreak int       `json:"longest_kong_streak"`
+		CurrentNgokStreak int       `json:"current_ngok_streak"`
+		LongestNgokStreak int       `json:"longest_ngok_streak"`
+	}
+)
+
+// Import
+type (
+	ImportClaimsRequest struct {
+		DryRun bool `form:"dry_run"`
+	}
+	ImportRowResult struct {
+		Row     int        `json:"row"`
+		Status  string     `json:"status"`
+		Error   string     `json:"error,omitempty"`
+		ClaimID *uuid.UUID `json:"claim_id,omitempty"`
+	}
+	ImportClaimsResponse struct {
+		DryRun    bool              `json:"dry_run"`
+		TotalRows int               `json:"total_rows"`
+		Valid     int               `json:"valid"`
+		Failed    int               `json:"failed"`
+		Imported  int               `json:"imported"`
+		Rows      []ImportRowResult `json:"rows"`
+	}
 )
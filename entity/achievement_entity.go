@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Achievement rows mirror the rule catalog in the service layer and are kept
+// in sync by code, so renaming a badge never orphans who earned it.
+type Achievement struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Code        string `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"`
+	Name        string `gorm:"type:varchar(100);not null" json:"name"`
+	Description string `gorm:"type:text" json:"description"`
+	Icon        string `gorm:"type:varchar(32)" json:"icon"`
+
+	TimeStamp
+}
+
+func (a *Achievement) BeforeCreate(tx *gorm.DB) (err error) {
+	a.ID = uuid.New()
+	return
+}
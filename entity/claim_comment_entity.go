@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ClaimComment struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Content string `gorm:"type:text;not null" json:"content"`
+
+	ClaimID uuid.UUID `gorm:"type:uuid;index;not null" json:"claim_id"`
+	Claim   Claim     `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"claim"`
+
+	AuthorID uuid.UUID `gorm:"type:uuid;index;not null" json:"author_id"`
+	Author   User      `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"author"`
+
+	ParentID *uuid.UUID     `gorm:"type:uuid;index" json:"parent_id"`
+	Parent   *ClaimComment  `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"parent,omitempty"`
+	Replies  []ClaimComment `gorm:"foreignKey:ParentID" json:"replies,omitempty"`
+
+	TimeStamp
+}
+
+func (cc *ClaimComment) BeforeCreate(tx *gorm.DB) (err error) {
+	cc.ID = uuid.New()
+	return
+}
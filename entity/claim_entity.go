@@ -19,6 +19,8 @@ type Claim struct {
 	ApproveCount int `gorm:"default:0" json:"approve_count"`
 	RejectCount  int `gorm:"default:0" json:"reject_count"`
 
+	IsImported bool `gorm:"default:false" json:"is_imported"`
+
 	ClaimedPlayerID uuid.UUID `gorm:"type:uuid;index;not null" json:"claimed_player_id"`
 	ClaimedPlayer   User      `gorm:"foreignKey:ClaimedPlayerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"claimed_player"`
 
@@ -31,7 +33,9 @@ type Claim struct {
 }
 
 func (c *Claim) BeforeCreate(tx *gorm.DB) (err error) {
-	c.ID = uuid.New()
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
 
 	if c.TotalPlayer <= 0 {
 		return err
@@ -39,3 +43,10 @@ func (c *Claim) BeforeCreate(tx *gorm.DB) (err error) {
 
 	return nil
 }
+
+// MatchKey identifies the match a claim belongs to. Claims from one match
+// share its date and the screenshot of its scoreboard, so several matches
+// played on the same day stay apart.
+func (c *Claim) MatchKey() string {
+	return c.MatchDate.Format("2006-01-02") + "|" + c.ScreenshotURL
+}
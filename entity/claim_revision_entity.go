@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ClaimRevision struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Action  RevisionAction `gorm:"type:varchar(20);not null" json:"action"`
+	Changes string         `gorm:"type:jsonb;not null;default:'[]'" json:"changes"`
+	Reason  string         `gorm:"type:text" json:"reason,omitempty"`
+
+	// ClaimID is cleared when the claim is purged, while SubjectClaimID keeps
+	// its ID so the trail can still be looked up afterwards.
+	ClaimID        *uuid.UUID `gorm:"type:uuid;index" json:"claim_id"`
+	Claim          Claim      `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"claim"`
+	SubjectClaimID uuid.UUID  `gorm:"type:uuid;index;not null" json:"subject_claim_id"`
+
+	ActorID uuid.UUID `gorm:"type:uuid;index;not null" json:"actor_id"`
+	Actor   User      `gorm:"foreignKey:ActorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"actor"`
+
+	TimeStamp
+}
+
+func (cr *ClaimRevision) BeforeCreate(tx *gorm.DB) (err error) {
+	cr.ID = uuid.New()
+	return
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Dispute struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Reason         string        `gorm:"type:text;not null" json:"reason"`
+	Status         DisputeStatus `gorm:"type:varchar(20);default:OPEN" json:"status"`
+	PreviousStatus ClaimStatus   `gorm:"type:varchar(20);not null" json:"previous_status"`
+	ResolvedAt     *time.Time    `json:"resolved_at"`
+
+	UpholdCount  int `gorm:"default:0" json:"uphold_count"`
+	DismissCount int `gorm:"default:0" json:"dismiss_count"`
+
+	ClaimID uuid.UUID `gorm:"type:uuid;index;not null" json:"claim_id"`
+	Claim   Claim     `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"claim"`
+
+	AppellantID uuid.UUID `gorm:"type:uuid;index;not null" json:"appellant_id"`
+	Appellant   User      `gorm:"foreignKey:AppellantID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"appellant"`
+
+	Votes []DisputeVote `gorm:"foreignKey:DisputeID;constraint:OnDelete:CASCADE;" json:"votes,omitempty"`
+
+	TimeStamp
+}
+
+func (d *Dispute) BeforeCreate(tx *gorm.DB) (err error) {
+	d.ID = uuid.New()
+	return
+}
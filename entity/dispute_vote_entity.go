@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DisputeVote struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	DisputeID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_dispute_voter" json:"dispute_id"`
+	Dispute   Dispute   `gorm:"foreignKey:DisputeID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"dispute"`
+
+	VoterID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_dispute_voter" json:"voter_id"`
+	Voter   User      `gorm:"foreignKey:VoterID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"voter"`
+
+	// APPROVE upholds the appeal, REJECT dismisses it
+	Type VoteType `gorm:"type:varchar(10);not null" json:"type"`
+
+	TimeStamp
+}
+
+func (dv *DisputeVote) BeforeCreate(tx *gorm.DB) (err error) {
+	dv.ID = uuid.New()
+	return
+}
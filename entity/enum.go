@@ -1,9 +1,13 @@
 package entity
 
 type (
-	ClaimEvent  string
-	ClaimStatus string
-	VoteType    string
+	ClaimEvent    string
+	ClaimStatus   string
+	VoteType      string
+	DisputeStatus string
+
+	RevisionAction   string
+	NotificationType string
 )
 
 const (
@@ -16,9 +20,38 @@ const (
 	StatusPending       ClaimStatus = "PENDING"
 	StatusFinalApproved ClaimStatus = "FINAL_APPROVED"
 	StatusFinalRejected ClaimStatus = "FINAL_REJECTED"
+	StatusDisputed      ClaimStatus = "DISPUTED"
+	StatusCancelled     ClaimStatus = "CANCELLED"
+	StatusExpired       ClaimStatus = "EXPIRED"
 )
 
 const (
 	VoteApprove VoteType = "APPROVE"
 	VoteReject  VoteType = "REJECT"
 )
+
+const (
+	RevisionCreate       RevisionAction = "CREATE"
+	RevisionUpdate       RevisionAction = "UPDATE"
+	RevisionDelete       RevisionAction = "DELETE"
+	RevisionStatusChange RevisionAction = "STATUS_CHANGE"
+	RevisionRestore      RevisionAction = "RESTORE"
+	RevisionPurge        RevisionAction = "PURGE"
+)
+
+const (
+	NotificationVoteReset     NotificationType = "VOTE_RESET"
+	NotificationClaimReopened NotificationType = "CLAIM_REOPENED"
+	NotificationClaimResolved NotificationType = "CLAIM_RESOLVED"
+	NotificationDisputeOpened NotificationType = "DISPUTE_OPENED"
+	NotificationDisputeClosed NotificationType = "DISPUTE_CLOSED"
+	NotificationNewComment    NotificationType = "NEW_COMMENT"
+	NotificationCommentReply  NotificationType = "COMMENT_REPLY"
+	NotificationAchievement   NotificationType = "ACHIEVEMENT"
+)
+
+const (
+	DisputeOpen      DisputeStatus = "OPEN"
+	DisputeUpheld    DisputeStatus = "UPHELD"
+	DisputeDismissed DisputeStatus = "DISMISSED"
+)
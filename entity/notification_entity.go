@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Notification struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Type    NotificationType `gorm:"type:varchar(30);not null" json:"type"`
+	Message string           `gorm:"not null" json:"message"`
+	IsRead  bool             `gorm:"default:false" json:"is_read"`
+
+	UserID uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user"`
+
+	ClaimID *uuid.UUID `gorm:"type:uuid;index" json:"claim_id"`
+	Claim   *Claim     `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"claim,omitempty"`
+
+	TimeStamp
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) (err error) {
+	n.ID = uuid.New()
+	return
+}
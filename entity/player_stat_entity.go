@@ -13,8 +13,17 @@ type PlayerStat struct {
 	KongCount  int `gorm:"default:0" json:"kong_count"`
 	NgokCount  int `gorm:"default:0" json:"ngok_count"`
 
-	Score   int     `gorm:"default:0" json:"score"`
-	WinRate float64 `gorm:"default:0" json:"win_rate"`
+	Score          int     `gorm:"default:0" json:"score"`
+	ScoringVersion int     `gorm:"default:0" json:"scoring_version"`
+	WinRate        float64 `gorm:"default:0" json:"win_rate"`
+	Rating         float64 `gorm:"default:1500" json:"rating"`
+
+	CurrentKingStreak int `gorm:"default:0" json:"current_king_streak"`
+	LongestKingStreak int `gorm:"default:0" json:"longest_king_streak"`
+	CurrentKongStreak int `gorm:"default:0" json:"current_kong_streak"`
+	LongestKongStreak int `gorm:"default:0" json:"longest_kong_streak"`
+	CurrentNgokStreak int `gorm:"default:0" json:"current_ngok_streak"`
+	LongestNgokStreak int `gorm:"default:0" json:"longest_ngok_streak"`
 
 	PlayerID uuid.UUID `gorm:"type:uuid;uniqueIndex;not null" json:"player_id"`
 	Player   User      `gorm:"foreignKey:PlayerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"player"`
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InitialRating is every player's rating before their first approved match.
+const InitialRating = 1500.0
+
+// RatingHistory is one player's rating change for one match day. The table is
+// rebuilt by replaying approved claims, so rows are never edited in place.
+type RatingHistory struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	MatchDate    time.Time `gorm:"type:date;not null;index" json:"match_date"`
+	RatingBefore float64   `gorm:"not null" json:"rating_before"`
+	RatingAfter  float64   `gorm:"not null" json:"rating_after"`
+	Delta        float64   `gorm:"not null" json:"delta"`
+	Opponents    int       `gorm:"not null" json:"opponents"`
+
+	PlayerID uuid.UUID `gorm:"type:uuid;index;not null" json:"player_id"`
+	Player   User      `gorm:"foreignKey:PlayerID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"player"`
+
+	TimeStamp
+}
+
+func (rh *RatingHistory) BeforeCreate(tx *gorm.DB) (err error) {
+	rh.ID = uuid.New()
+	return
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Reaction struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Emoji string `gorm:"type:varchar(32);not null;uniqueIndex:idx_claim_user_emoji" json:"emoji"`
+
+	ClaimID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_claim_user_emoji" json:"claim_id"`
+	Claim   Claim     `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"claim"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_claim_user_emoji" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user"`
+
+	TimeStamp
+}
+
+func (r *Reaction) BeforeCreate(tx *gorm.DB) (err error) {
+	r.ID = uuid.New()
+	return
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScoringRule is append-only: every edit is stored as a new version so stats
+// can tell which rules they were computed with.
+type ScoringRule struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	Version    int `gorm:"uniqueIndex;not null" json:"version"`
+	KingPoints int `gorm:"not null" json:"king_points"`
+	KongPoints int `gorm:"not null" json:"kong_points"`
+	NgokPoints int `gorm:"not null" json:"ngok_points"`
+
+	ScaleByTotalPlayer bool `gorm:"default:false" json:"scale_by_total_player"`
+	BaselinePlayers    int  `gorm:"default:2" json:"baseline_players"`
+
+	CreatedByID *uuid.UUID `gorm:"type:uuid;index" json:"created_by_id"`
+	CreatedBy   *User      `gorm:"foreignKey:CreatedByID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"created_by"`
+
+	TimeStamp
+}
+
+func (sr *ScoringRule) BeforeCreate(tx *gorm.DB) (err error) {
+	sr.ID = uuid.New()
+	return
+}
+
+// DefaultScoringRule is used until an admin saves the first version.
+func DefaultScoringRule() *ScoringRule {
+	return &ScoringRule{
+		Version:         0,
+		KingPoints:      3,
+		KongPoints:      1,
+		NgokPoints:      -1,
+		BaselinePlayers: 2,
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserAchievement struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	UserID uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_achievement" json:"user_id"`
+	User   User      `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"user"`
+
+	AchievementID uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_user_achievement" json:"achievement_id"`
+	Achievement   Achievement `gorm:"foreignKey:AchievementID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"achievement"`
+
+	// ClaimID is the finalized claim whose evaluation awarded the badge.
+	ClaimID *uuid.UUID `gorm:"type:uuid;index" json:"claim_id"`
+	Claim   *Claim     `gorm:"foreignKey:ClaimID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"claim"`
+
+	TimeStamp
+}
+
+func (ua *UserAchievement) BeforeCreate(tx *gorm.DB) (err error) {
+	ua.ID = uuid.New()
+	return
+}
@@ -10,6 +10,7 @@ type User struct {
 	Password  string    `gorm:"not null" json:"password"`
 	AvatarURL string    `json:"avatar_url,omitempty"`
 	IsActive  bool      `gorm:"default:true" json:"is_active"`
+	Role      string    `gorm:"type:varchar(10);default:user" json:"role"`
 
 	ReportedClaims []Claim `gorm:"foreignKey:ReporterID;constraint:OnDelete:SET NULL;" json:"reported_claims,omitempty"`
 	ClaimedEvents  []Claim `gorm:"foreignKey:ClaimedPlayerID;constraint:OnDelete:CASCADE;" json:"claimed_events,omitempty"`
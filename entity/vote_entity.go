@@ -20,6 +20,8 @@ type Vote struct {
 }
 
 func (v *Vote) BeforeCreate(tx *gorm.DB) (err error) {
-	v.ID = uuid.New()
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
 	return
 }
@@ -28,15 +28,13 @@ func NewAuthHandler(authService service.IAuthService) *authHandler {
 func (ah *authHandler) Login(ctx *gin.Context) {
 	var payload dto.LoginRequest
 	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
 		return
 	}
 
 	result, err := ah.authService.Login(ctx, payload)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.FAILED_LOGIN, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, dto.FAILED_LOGIN, err)
 		return
 	}
 
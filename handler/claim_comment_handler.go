@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IClaimCommentHandler interface {
+		Create(ctx *gin.Context)
+		GetAllByClaimID(ctx *gin.Context)
+		Update(ctx *gin.Context)
+		DeleteByID(ctx *gin.Context)
+	}
+
+	claimCommentHandler struct {
+		claimCommentService service.IClaimCommentService
+	}
+)
+
+func NewClaimCommentHandler(claimCommentService service.IClaimCommentService) *claimCommentHandler {
+	return &claimCommentHandler{
+		claimCommentService: claimCommentService,
+	}
+}
+
+func (cch *claimCommentHandler) Create(ctx *gin.Context) {
+	payload := &dto.CreateClaimCommentRequest{}
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ClaimID = claimID
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := cch.claimCommentService.Create(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s comment", dto.FAILED_CREATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s comment", dto.SUCCESS_CREATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (cch *claimCommentHandler) GetAllByClaimID(ctx *gin.Context) {
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := cch.claimCommentService.GetAllByClaimID(ctx, &claimID)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s comments", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s comments", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (cch *claimCommentHandler) Update(ctx *gin.Context) {
+	payload := &dto.UpdateClaimCommentRequest{}
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	id, err := uuid.Parse(ctx.Param("comment_id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ClaimID = claimID
+	payload.ID = id
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := cch.claimCommentService.Update(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s comment", dto.FAILED_UPDATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s comment", dto.SUCCESS_UPDATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (cch *claimCommentHandler) DeleteByID(ctx *gin.Context) {
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	id, err := uuid.Parse(ctx.Param("comment_id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := cch.claimCommentService.DeleteByID(ctx, &claimID, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s comment", dto.FAILED_DELETE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s comment", dto.SUCCESS_DELETE), result)
+	ctx.JSON(http.StatusOK, res)
+}
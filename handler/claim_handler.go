@@ -21,6 +21,19 @@ type (
 
 		Vote(ctx *gin.Context)
 		GetAllVotesByClaimID(ctx *gin.Context)
+
+		GetHistoryByClaimID(ctx *gin.Context)
+
+		Reopen(ctx *gin.Context)
+		Cancel(ctx *gin.Context)
+		Resolve(ctx *gin.Context)
+		GetAllDeleted(ctx *gin.Context)
+		Restore(ctx *gin.Context)
+		Purge(ctx *gin.Context)
+
+		OpenDispute(ctx *gin.Context)
+		VoteDispute(ctx *gin.Context)
+		GetAllDisputesByClaimID(ctx *gin.Context)
 	}
 
 	claimHandler struct {
@@ -37,15 +50,13 @@ func NewClaimHandler(claimService service.IClaimService) *claimHandler {
 func (ch *claimHandler) Create(ctx *gin.Context) {
 	payload := &dto.CreateClaimRequest{}
 	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
 		return
 	}
 
 	result, err := ch.claimService.Create(ctx, payload)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s claim", dto.FAILED_CREATE), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s claim", dto.FAILED_CREATE), err)
 		return
 	}
 
@@ -56,15 +67,19 @@ func (ch *claimHandler) Create(ctx *gin.Context) {
 func (ch *claimHandler) GetAll(ctx *gin.Context) {
 	var pagination response.PaginationRequest
 	if err := ctx.ShouldBindQuery(&pagination); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	var filter dto.ClaimFilterRequest
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 
-	result, err := ch.claimService.GetAllWithPagination(ctx, pagination)
+	result, err := ch.claimService.GetAllWithPagination(ctx, pagination, filter)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s claims", dto.FAILED_GET_ALL), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s claims", dto.FAILED_GET_ALL), err)
 		return
 	}
 
@@ -81,15 +96,13 @@ func (ch *claimHandler) GetDetailByID(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 
 	result, err := ch.claimService.GetDetailByID(ctx, &id)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s claim", dto.FAILED_GET_DETAIL), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s claim", dto.FAILED_GET_DETAIL), err)
 		return
 	}
 
@@ -102,22 +115,19 @@ func (ch *claimHandler) Update(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 	payload.ID = id
 
 	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
 		return
 	}
 
 	result, err := ch.claimService.Update(ctx, payload)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s claim", dto.FAILED_UPDATE), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s claim", dto.FAILED_UPDATE), err)
 		return
 	}
 
@@ -129,15 +139,13 @@ func (ch *claimHandler) DeleteByID(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 
 	result, err := ch.claimService.DeleteByID(ctx, &id)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s claim", dto.FAILED_DELETE), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s claim", dto.FAILED_DELETE), err)
 		return
 	}
 
@@ -150,22 +158,19 @@ func (ch *claimHandler) Vote(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 	payload.ID = id
 
 	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
 		return
 	}
 
 	result, err := ch.claimService.Vote(ctx, payload)
 	if err != nil {
-		res := response.BuildResponseFailed("failed to vote claim", err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, "failed to vote claim", err)
 		return
 	}
 
@@ -177,18 +182,226 @@ func (ch *claimHandler) GetAllVotesByClaimID(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_QUERY_PARAMS, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
 		return
 	}
 
 	result, err := ch.claimService.GetAllVotesByClaimID(ctx, &id)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s votes", dto.FAILED_GET_ALL), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s votes", dto.FAILED_GET_ALL), err)
 		return
 	}
 
 	res := response.BuildResponseSuccess(fmt.Sprintf("%s votes", dto.SUCCESS_GET_ALL), result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (ch *claimHandler) GetHistoryByClaimID(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.GetHistoryByClaimID(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s claim history", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s claim history", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) Reopen(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.Reopen(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, "failed to reopen claim", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to reopen claim", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) Cancel(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.Cancel(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, "failed to cancel claim", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to cancel claim", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) Resolve(ctx *gin.Context) {
+	payload := &dto.ResolveClaimRequest{}
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ID = id
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := ch.claimService.Resolve(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, "failed to resolve claim", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to resolve claim", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) GetAllDeleted(ctx *gin.Context) {
+	var pagination response.PaginationRequest
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.GetAllDeletedWithPagination(ctx, pagination)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s deleted claims", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.Response{
+		Status:   true,
+		Messsage: fmt.Sprintf("%s deleted claims", dto.SUCCESS_GET_ALL),
+		Data:     result.Data,
+		Meta:     result.PaginationResponse,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) Restore(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.Restore(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, "failed to restore claim", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to restore claim", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) Purge(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.Purge(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, "failed to purge claim", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to purge claim", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) OpenDispute(ctx *gin.Context) {
+	payload := &dto.CreateDisputeRequest{}
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ClaimID = id
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := ch.claimService.OpenDispute(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s dispute", dto.FAILED_CREATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s dispute", dto.SUCCESS_CREATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) VoteDispute(ctx *gin.Context) {
+	payload := &dto.DisputeVoteRequest{}
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	disputeID, err := uuid.Parse(ctx.Param("dispute_id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ClaimID = claimID
+	payload.DisputeID = disputeID
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := ch.claimService.VoteDispute(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, "failed to vote dispute", err)
+		return
+	}
+
+	res := response.BuildResponseSuccess("success to vote dispute", result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (ch *claimHandler) GetAllDisputesByClaimID(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := ch.claimService.GetAllDisputesByClaimID(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s disputes", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s disputes", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IExportHandler interface {
+		ExportClaims(ctx *gin.Context)
+		ExportStats(ctx *gin.Context)
+	}
+
+	exportHandler struct {
+		exportService service.IExportService
+	}
+)
+
+func NewExportHandler(exportService service.IExportService) *exportHandler {
+	return &exportHandler{
+		exportService: exportService,
+	}
+}
+
+func (eh *exportHandler) ExportClaims(ctx *gin.Context) {
+	var payload dto.ExportClaimsRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	setExportHeaders(ctx, "claims", payload.Format)
+	if err := eh.exportService.ExportClaims(ctx, &payload, ctx.Writer); err != nil {
+		abortExport(ctx, "claims", err)
+	}
+}
+
+func (eh *exportHandler) ExportStats(ctx *gin.Context) {
+	var payload dto.ExportStatsRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	setExportHeaders(ctx, "player_stats", payload.Format)
+	if err := eh.exportService.ExportStats(ctx, &payload, ctx.Writer); err != nil {
+		abortExport(ctx, "player stats", err)
+	}
+}
+
+func setExportHeaders(ctx *gin.Context, name string, format string) {
+	if format == constants.ENUM_EXPORT_FORMAT_NDJSON {
+		ctx.Header("Content-Type", "application/x-ndjson")
+	} else {
+		format = constants.ENUM_EXPORT_FORMAT_CSV
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	ctx.Status(http.StatusOK)
+}
+
+// abortExport reports a failed export. Once rows have been streamed the status
+// line is already sent, so the error can only be recorded on the context.
+func abortExport(ctx *gin.Context, name string, err error) {
+	if ctx.Writer.Written() {
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	ctx.Writer.Header().Del("Content-Type")
+	ctx.Writer.Header().Del("Content-Disposition")
+	abortWithError(ctx, fmt.Sprintf("%s %s", dto.FAILED_GET_ALL, name), err)
+}
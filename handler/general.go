@@ -1,42 +1,53 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
+	"github.com/Amierza/mc-kalak-backend/apperror"
 	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/gin-gonic/gin"
 )
 
 func mapErrorStatus(err error) int {
-	switch {
-	case errors.Is(err, dto.ErrNotFound):
+	switch apperror.CodeOf(err) {
+	case apperror.CodeNotFound:
 		return http.StatusNotFound
-	case errors.Is(err, dto.ErrValidationFailed):
+	case apperror.CodeValidation:
 		return http.StatusBadRequest
-	case errors.Is(err, dto.ErrAlreadyExists):
+	case apperror.CodeConflict, apperror.CodeInvalidTransition:
 		return http.StatusConflict
-	case errors.Is(err, dto.ErrUnauthorized):
+	case apperror.CodeUnauthorized:
 		return http.StatusUnauthorized
+	case apperror.CodeForbidden:
+		return http.StatusForbidden
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
-func mapErrorMessage(err error) string {
-	switch {
-	case errors.Is(err, dto.ErrNotFound):
-		return "data not found"
-	case errors.Is(err, dto.ErrValidationFailed):
-		if !errors.Is(err, fmt.Errorf("validation failed")) {
-			return err.Error()
-		}
-		return "validation failed"
-	case errors.Is(err, dto.ErrAlreadyExists):
-		return "data already exists"
-	case errors.Is(err, dto.ErrUnauthorized):
-		return "unauthorized access"
-	default:
-		return "internal server error"
+const internalErrorMessage = "internal server error"
+
+// abortWithError writes a failed response whose status and code follow the
+// domain error carried by err. Internal errors are only attached to the
+// context, where RequestLogger logs them with the request ID, so their
+// details never reach the client.
+func abortWithError(ctx *gin.Context, message string, err error) {
+	code := apperror.CodeOf(err)
+	detail := err.Error()
+	if code == apperror.CodeInternal {
+		_ = ctx.Error(err)
+		detail = internalErrorMessage
 	}
+
+	res := response.BuildResponseFailed(message, detail, nil)
+	res.Code = string(code)
+	ctx.AbortWithStatusJSON(mapErrorStatus(err), res)
+}
+
+// abortInvalidRequest reports a payload, query or path parameter that could
+// not be bound as a validation failure.
+func abortInvalidRequest(ctx *gin.Context, message string, err error) {
+	abortWithError(ctx, message, fmt.Errorf("%w: %w", dto.ErrValidationFailed, err))
 }
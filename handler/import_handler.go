@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IImportHandler interface {
+		ImportClaims(ctx *gin.Context)
+	}
+
+	importHandler struct {
+		importService service.IImportService
+	}
+)
+
+func NewImportHandler(importService service.IImportService) *importHandler {
+	return &importHandler{
+		importService: importService,
+	}
+}
+
+func (ih *importHandler) ImportClaims(ctx *gin.Context) {
+	var payload dto.ImportClaimsRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_NO_FILES_UPLOADED, dto.ErrNoFilesUploaded)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		abortWithError(ctx, dto.MESSAGE_FAILED_UPLOAD_FILES, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := ih.importService.ImportClaims(ctx, &payload, file)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s claims", dto.FAILED_CREATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s claims", dto.SUCCESS_CREATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
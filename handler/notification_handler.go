@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	INotificationHandler interface {
+		GetAll(ctx *gin.Context)
+		MarkAsRead(ctx *gin.Context)
+	}
+
+	notificationHandler struct {
+		notificationService service.INotificationService
+	}
+)
+
+func NewNotificationHandler(notificationService service.INotificationService) *notificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+func (nh *notificationHandler) GetAll(ctx *gin.Context) {
+	result, err := nh.notificationService.GetAll(ctx)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s notifications", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s notifications", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (nh *notificationHandler) MarkAsRead(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := nh.notificationService.MarkAsRead(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s notification", dto.FAILED_UPDATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s notification", dto.SUCCESS_UPDATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type (
+	IReactionHandler interface {
+		Add(ctx *gin.Context)
+		Remove(ctx *gin.Context)
+	}
+
+	reactionHandler struct {
+		reactionService service.IReactionService
+	}
+)
+
+func NewReactionHandler(reactionService service.IReactionService) *reactionHandler {
+	return &reactionHandler{
+		reactionService: reactionService,
+	}
+}
+
+func (rh *reactionHandler) Add(ctx *gin.Context) {
+	payload := &dto.ReactionRequest{}
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload.ClaimID = claimID
+
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := rh.reactionService.Add(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s reaction", dto.FAILED_CREATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s reaction", dto.SUCCESS_CREATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (rh *reactionHandler) Remove(ctx *gin.Context) {
+	claimID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+	payload := &dto.ReactionRequest{
+		ClaimID: claimID,
+		Emoji:   ctx.Param("emoji"),
+	}
+
+	result, err := rh.reactionService.Remove(ctx, payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s reaction", dto.FAILED_DELETE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s reaction", dto.SUCCESS_DELETE), result)
+	ctx.JSON(http.StatusOK, res)
+}
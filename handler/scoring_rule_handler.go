@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IScoringRuleHandler interface {
+		GetCurrent(ctx *gin.Context)
+		GetAll(ctx *gin.Context)
+		Update(ctx *gin.Context)
+	}
+
+	scoringRuleHandler struct {
+		scoringRuleService service.IScoringRuleService
+	}
+)
+
+func NewScoringRuleHandler(scoringRuleService service.IScoringRuleService) *scoringRuleHandler {
+	return &scoringRuleHandler{
+		scoringRuleService: scoringRuleService,
+	}
+}
+
+func (srh *scoringRuleHandler) GetCurrent(ctx *gin.Context) {
+	result, err := srh.scoringRuleService.GetCurrent(ctx)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s scoring rule", dto.FAILED_GET_DETAIL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s scoring rule", dto.SUCCESS_GET_DETAIL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (srh *scoringRuleHandler) GetAll(ctx *gin.Context) {
+	result, err := srh.scoringRuleService.GetAll(ctx)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s scoring rules", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s scoring rules", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (srh *scoringRuleHandler) Update(ctx *gin.Context) {
+	var payload dto.UpdateScoringRuleRequest
+	if err := ctx.ShouldBind(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
+		return
+	}
+
+	result, err := srh.scoringRuleService.Update(ctx, &payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s scoring rule", dto.FAILED_UPDATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s scoring rule", dto.SUCCESS_UPDATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/response"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	IStatHandler interface {
+		RecomputeAll(ctx *gin.Context)
+		GetLeaderboard(ctx *gin.Context)
+		GetHeadToHead(ctx *gin.Context)
+		GetVoterStats(ctx *gin.Context)
+	}
+
+	statHandler struct {
+		statService service.IStatService
+	}
+)
+
+func NewStatHandler(statService service.IStatService) *statHandler {
+	return &statHandler{
+		statService: statService,
+	}
+}
+
+func (sh *statHandler) RecomputeAll(ctx *gin.Context) {
+	var payload dto.RecomputeStatsRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := sh.statService.RecomputeAll(ctx, payload.DryRun)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s player stats", dto.FAILED_UPDATE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s player stats", dto.SUCCESS_UPDATE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (sh *statHandler) GetLeaderboard(ctx *gin.Context) {
+	var payload dto.LeaderboardRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := sh.statService.GetLeaderboard(ctx, &payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s leaderboard", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s leaderboard", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (sh *statHandler) GetHeadToHead(ctx *gin.Context) {
+	var payload dto.HeadToHeadRequest
+	if err := ctx.ShouldBindQuery(&payload); err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := sh.statService.GetHeadToHead(ctx, &payload)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s head to head", dto.FAILED_GET_DETAIL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s head to head", dto.SUCCESS_GET_DETAIL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (sh *statHandler) GetVoterStats(ctx *gin.Context) {
+	result, err := sh.statService.GetVoterStats(ctx)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s voter stats", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s voter stats", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
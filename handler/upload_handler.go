@@ -28,23 +28,13 @@ func NewUploadHandler(uploadService service.IUploadService) *uploadHandler {
 func (uh *uploadHandler) Upload(ctx *gin.Context) {
 	file, err := ctx.FormFile("screenshot")
 	if err != nil {
-		res := response.BuildResponseFailed(
-			dto.MESSAGE_FAILED_NO_FILES_UPLOADED,
-			dto.ErrNoFilesUploaded.Error(),
-			nil,
-		)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, dto.MESSAGE_FAILED_NO_FILES_UPLOADED, dto.ErrNoFilesUploaded)
 		return
 	}
 
 	uploadedURL, err := uh.uploadService.Upload(ctx, file)
 	if err != nil {
-		res := response.BuildResponseFailed(
-			dto.MESSAGE_FAILED_UPLOAD_FILES,
-			err.Error(),
-			nil,
-		)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, dto.MESSAGE_FAILED_UPLOAD_FILES, err)
 		return
 	}
 
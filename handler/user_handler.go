@@ -8,12 +8,16 @@ import (
 	"github.com/Amierza/mc-kalak-backend/response"
 	"github.com/Amierza/mc-kalak-backend/service"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type (
 	IUserHandler interface {
 		GetProfile(ctx *gin.Context)
 		Update(ctx *gin.Context)
+		GetRatingHistory(ctx *gin.Context)
+		GetBadges(ctx *gin.Context)
+		GetProfileStats(ctx *gin.Context)
 	}
 
 	userHandler struct {
@@ -30,8 +34,7 @@ func NewUserHandler(userService service.IUserService) *userHandler {
 func (uh *userHandler) GetProfile(ctx *gin.Context) {
 	result, err := uh.userService.GetProfile(ctx)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s user", dto.FAILED_GET_PROFILE), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s user", dto.FAILED_GET_PROFILE), err)
 		return
 	}
 
@@ -39,21 +42,66 @@ func (uh *userHandler) GetProfile(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (uh *userHandler) GetProfileStats(ctx *gin.Context) {
+	result, err := uh.userService.GetProfileStats(ctx)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s profile stats", dto.FAILED_GET_PROFILE), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s profile stats", dto.SUCCESS_GET_PROFILE), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (uh *userHandler) Update(ctx *gin.Context) {
 	payload := &dto.UpdateProfileRequest{}
 	if err := ctx.ShouldBind(&payload); err != nil {
-		res := response.BuildResponseFailed(dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_REQUEST_PAYLOAD, err)
 		return
 	}
 
 	result, err := uh.userService.Update(ctx, payload)
 	if err != nil {
-		res := response.BuildResponseFailed(fmt.Sprintf("%s user", dto.FAILED_UPDATE), err.Error(), nil)
-		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		abortWithError(ctx, fmt.Sprintf("%s user", dto.FAILED_UPDATE), err)
 		return
 	}
 
 	res := response.BuildResponseSuccess(fmt.Sprintf("%s user", dto.SUCCESS_UPDATE), result)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (uh *userHandler) GetRatingHistory(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := uh.userService.GetRatingHistory(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s rating history", dto.FAILED_GET_DETAIL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s rating history", dto.SUCCESS_GET_DETAIL), result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (uh *userHandler) GetBadges(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		abortInvalidRequest(ctx, dto.MESSAGE_INVALID_QUERY_PARAMS, err)
+		return
+	}
+
+	result, err := uh.userService.GetBadges(ctx, &id)
+	if err != nil {
+		abortWithError(ctx, fmt.Sprintf("%s badges", dto.FAILED_GET_ALL), err)
+		return
+	}
+
+	res := response.BuildResponseSuccess(fmt.Sprintf("%s badges", dto.SUCCESS_GET_ALL), result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,22 @@
+package helper
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsValidEmoji rejects plain text reactions. Emoji sequences such as flags or
+// skin tones span several runes, so a handful are allowed.
+func IsValidEmoji(emoji string) bool {
+	if emoji == "" || utf8.RuneCountInString(emoji) > 8 {
+		return false
+	}
+
+	for _, r := range emoji {
+		if r < unicode.MaxASCII || unicode.IsSpace(r) || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
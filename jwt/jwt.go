@@ -11,13 +11,15 @@ import (
 
 type (
 	IJWT interface {
-		GenerateToken(userID string) (string, error)
+		GenerateToken(userID, role string) (string, error)
 		ValidateToken(token string) (*jwt.Token, error)
 		GetUserIDByToken(tokenString string) (string, error)
+		GetRoleByToken(tokenString string) (string, error)
 	}
 
 	jwtCustomClaim struct {
 		UserID string `json:"user_id"`
+		Role   string `json:"role"`
 		jwt.RegisteredClaims
 	}
 
@@ -43,9 +45,10 @@ func getSecretKey() string {
 	return secretKey
 }
 
-func (j *JWT) GenerateToken(userID string) (string, error) {
+func (j *JWT) GenerateToken(userID, role string) (string, error) {
 	claims := jwtCustomClaim{
 		userID,
+		role,
 		jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Second * 3600 * 24 * 7)),
 			Issuer:    j.issuer,
@@ -95,7 +98,7 @@ func (j *JWT) GetUserIDByToken(tokenString string) (string, error) {
 	return userID, nil
 }
 
-func (j *JWT) GetRoleIDByToken(tokenString string) (string, error) {
+func (j *JWT) GetRoleByToken(tokenString string) (string, error) {
 	token, err := j.ValidateToken(tokenString)
 	if err != nil {
 		return "", dto.ErrValidateToken
@@ -106,7 +109,7 @@ func (j *JWT) GetRoleIDByToken(tokenString string) (string, error) {
 		return "", dto.ErrTokenInvalid
 	}
 
-	roleID := fmt.Sprintf("%v", claims["role_id"])
+	role, _ := claims["role"].(string)
 
-	return roleID, nil
+	return role, nil
 }
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// slowQueryThreshold is how long a query may take before it is logged as a
+// warning.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger sends GORM output through zap. Repositories pass the request
+// context down with WithContext, so every query line carries the request ID
+// and user ID.
+type gormLogger struct {
+	log   *zap.Logger
+	level gormlogger.LogLevel
+}
+
+func NewGormLogger(log *zap.Logger) gormlogger.Interface {
+	return &gormLogger{
+		log:   log,
+		level: gormlogger.Info,
+	}
+}
+
+func (gl *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *gl
+	clone.level = level
+	return &clone
+}
+
+func (gl *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Info {
+		FromContext(ctx, gl.log).Sugar().Infof(msg, args...)
+	}
+}
+
+func (gl *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Warn {
+		FromContext(ctx, gl.log).Sugar().Warnf(msg, args...)
+	}
+}
+
+func (gl *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if gl.level >= gormlogger.Error {
+		FromContext(ctx, gl.log).Sugar().Errorf(msg, args...)
+	}
+}
+
+func (gl *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if gl.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	log := FromContext(ctx, gl.log).With(zap.String("source", utils.FileWithLineNum()))
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && gl.level >= gormlogger.Error:
+		sql, rows := fc()
+		log.Error("query failed", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed), zap.Error(err))
+	case elapsed > slowQueryThreshold && gl.level >= gormlogger.Warn:
+		sql, rows := fc()
+		log.Warn("slow query", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	case gl.level >= gormlogger.Info && log.Core().Enabled(zap.DebugLevel):
+		sql, rows := fc()
+		log.Debug("query", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	}
+}
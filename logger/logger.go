@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	RequestIDKey = "request_id"
+	UserIDKey    = "user_id"
+)
+
+// New builds the application logger. LOG_LEVEL picks the minimum level
+// (debug, info, warn, error) and defaults to info; production gets JSON
+// output, every other environment a readable console format.
+func New() *zap.Logger {
+	level := zapcore.InfoLevel
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		parsed, err := zapcore.ParseLevel(value)
+		if err != nil {
+			panic(fmt.Errorf("invalid LOG_LEVEL %q: %v", value, err))
+		}
+		level = parsed
+	}
+
+	config := zap.NewDevelopmentConfig()
+	if os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION {
+		config = zap.NewProductionConfig()
+	}
+	config.Level = zap.NewAtomicLevelAt(level)
+
+	log, err := config.Build()
+	if err != nil {
+		panic(fmt.Errorf("failed to build logger: %v", err))
+	}
+
+	return log
+}
+
+// FromContext returns log tagged with the request ID and the authenticated
+// user found in ctx, so lines from every layer of one request can be joined.
+func FromContext(ctx context.Context, log *zap.Logger) *zap.Logger {
+	if ctx == nil {
+		return log
+	}
+
+	var fields []zap.Field
+	if requestID, ok := ctx.Value(RequestIDKey).(string); ok && requestID != "" {
+		fields = append(fields, zap.String(RequestIDKey, requestID))
+	}
+	if userID, ok := ctx.Value(UserIDKey).(string); ok && userID != "" {
+		fields = append(fields, zap.String(UserIDKey, userID))
+	}
+
+	if len(fields) == 0 {
+		return log
+	}
+
+	return log.With(fields...)
+}
@@ -1,26 +1,33 @@
 package main
 
 import (
-	"log"
 	"os"
 
 	"github.com/Amierza/mc-kalak-backend/cmd"
+	"github.com/Amierza/mc-kalak-backend/config"
 	"github.com/Amierza/mc-kalak-backend/config/database"
 	"github.com/Amierza/mc-kalak-backend/handler"
 	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/logger"
 	"github.com/Amierza/mc-kalak-backend/middleware"
 	"github.com/Amierza/mc-kalak-backend/repository"
 	"github.com/Amierza/mc-kalak-backend/routes"
 	"github.com/Amierza/mc-kalak-backend/service"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 func main() {
-	db := database.SetUpPostgreSQLConnection()
-	defer database.ClosePostgreSQLConnection(db)
+	config.LoadEnv()
+
+	appLogger := logger.New()
+	defer appLogger.Sync()
+
+	db := database.SetUpPostgreSQLConnection(appLogger)
+	defer database.ClosePostgreSQLConnection(db, appLogger)
 
 	if len(os.Args) > 1 {
-		cmd.Command(db)
+		cmd.Command(db, appLogger)
 		return
 	}
 
@@ -29,35 +36,101 @@ func main() {
 		jwt = jwt.NewJWT()
 
 		// Resource
+		// Rating History
+		ratingHistoryRepo = repository.NewRatingHistoryRepository(db)
+
+		// Achievement
+		achievementRepo = repository.NewAchievementRepository(db)
+
+		// Player Stat
+		playerStatRepo = repository.NewPlayerStatRepository(db)
+
+		// User Stat
+		userStatRepo = repository.NewUserStatRepository(db)
+
+		// Voter Stat
+		voterStatRepo = repository.NewVoterStatRepository(db)
+
 		// User
 		userRepo    = repository.NewUserRepository(db)
-		userService = service.NewUserService(userRepo, jwt)
+		userService = service.NewUserService(userRepo, ratingHistoryRepo, achievementRepo, playerStatRepo, userStatRepo, jwt, appLogger)
 		userHandler = handler.NewUserHandler(userService)
 
 		// Authentication
-		authService = service.NewAuthService(userRepo, jwt)
+		authService = service.NewAuthService(userRepo, jwt, appLogger)
 		authHandler = handler.NewAuthHandler(authService)
 
 		// Upload
-		uploadService = service.NewUploadService()
+		uploadService = service.NewUploadService(appLogger)
 		uploadHandler = handler.NewUploadHandler(uploadService)
 
 		// Vote
 		voteRepo = repository.NewVoteRepository(db)
 
+		// Notification
+		notificationRepo    = repository.NewNotificationRepository(db)
+		notificationService = service.NewNotificationService(notificationRepo, jwt, appLogger)
+		notificationHandler = handler.NewNotificationHandler(notificationService)
+
+		// Dispute
+		disputeRepo     = repository.NewDisputeRepository(db)
+		disputeVoteRepo = repository.NewDisputeVoteRepository(db)
+
+		// Claim Comment
+		claimCommentRepo = repository.NewClaimCommentRepository(db)
+
+		// Reaction
+		reactionRepo = repository.NewReactionRepository(db)
+
+		// Scoring Rule
+		scoringRuleRepo    = repository.NewScoringRuleRepository(db)
+		scoringRuleService = service.NewScoringRuleService(db, scoringRuleRepo, playerStatRepo, appLogger)
+		scoringRuleHandler = handler.NewScoringRuleHandler(scoringRuleService)
+
+		// Claim Revision
+		claimRevisionRepo = repository.NewClaimRevisionRepository(db)
+
 		// Claim
 		claimRepo    = repository.NewClaimRepository(db)
-		claimService = service.NewClaimService(claimRepo, userRepo, voteRepo, jwt)
+		claimService = service.NewClaimService(db, claimRepo, userRepo, voteRepo, claimRevisionRepo, notificationRepo, playerStatRepo, disputeRepo, disputeVoteRepo, claimCommentRepo, reactionRepo, scoringRuleRepo, ratingHistoryRepo, achievementRepo, voterStatRepo, appLogger)
 		claimHandler = handler.NewClaimHandler(claimService)
+
+		// Stat
+		statService = service.NewStatService(db, playerStatRepo, scoringRuleRepo, claimRepo, ratingHistoryRepo, userRepo, voterStatRepo, appLogger)
+		statHandler = handler.NewStatHandler(statService)
+
+		// Claim Comment
+		claimCommentService = service.NewClaimCommentService(db, claimCommentRepo, claimRepo, userRepo, notificationRepo, appLogger)
+		claimCommentHandler = handler.NewClaimCommentHandler(claimCommentService)
+
+		// Reaction
+		reactionService = service.NewReactionService(reactionRepo, claimRepo, appLogger)
+		reactionHandler = handler.NewReactionHandler(reactionService)
+
+		// Export
+		exportRepo    = repository.NewExportRepository(db)
+		exportService = service.NewExportService(exportRepo, appLogger)
+		exportHandler = handler.NewExportHandler(exportService)
+
+		// Import
+		importService = service.NewImportService(db, claimRepo, userRepo, claimRevisionRepo, playerStatRepo, scoringRuleRepo, ratingHistoryRepo, voteRepo, achievementRepo, notificationRepo, appLogger)
+		importHandler = handler.NewImportHandler(importService)
 	)
 
-	server := gin.Default()
-	server.Use(middleware.CORSMiddleware())
+	server := gin.New()
+	server.Use(gin.Recovery(), middleware.RequestID(), middleware.RequestLogger(appLogger), middleware.CORSMiddleware())
 
 	routes.User(server, userHandler, jwt)
 	routes.Auth(server, authHandler, jwt)
 	routes.Upload(server, uploadHandler, jwt)
 	routes.Claim(server, claimHandler, jwt)
+	routes.ClaimComment(server, claimCommentHandler, jwt)
+	routes.Reaction(server, reactionHandler, jwt)
+	routes.Notification(server, notificationHandler, jwt)
+	routes.ScoringRule(server, scoringRuleHandler, jwt)
+	routes.Stat(server, statHandler, jwt)
+	routes.Export(server, exportHandler, jwt)
+	routes.Import(server, importHandler, jwt)
 
 	server.Static("/uploads", "./uploads")
 
@@ -74,6 +147,6 @@ func main() {
 	}
 
 	if err := server.Run(serve); err != nil {
-		log.Fatalf("error running server: %v", err)
+		appLogger.Fatal("error running server", zap.Error(err))
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/Amierza/mc-kalak-backend/apperror"
 	"github.com/Amierza/mc-kalak-backend/dto"
 	"github.com/Amierza/mc-kalak-backend/jwt"
 	"github.com/Amierza/mc-kalak-backend/response"
@@ -15,12 +16,14 @@ func Authentication(jwtService jwt.IJWT) gin.HandlerFunc {
 		authHeader := ctx.GetHeader("Authorization")
 		if authHeader == "" {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_FOUND, nil)
+			res.Code = string(apperror.CodeUnauthorized)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
 		if !strings.Contains(authHeader, "Bearer") {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
+			res.Code = string(apperror.CodeUnauthorized)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
@@ -29,12 +32,14 @@ func Authentication(jwtService jwt.IJWT) gin.HandlerFunc {
 		token, err := jwtService.ValidateToken(authHeader)
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
+			res.Code = string(apperror.CodeUnauthorized)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
 		if !token.Valid {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_DENIED_ACCESS, nil)
+			res.Code = string(apperror.CodeUnauthorized)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
@@ -42,12 +47,22 @@ func Authentication(jwtService jwt.IJWT) gin.HandlerFunc {
 		userID, err := jwtService.GetUserIDByToken(authHeader)
 		if err != nil {
 			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			res.Code = string(apperror.CodeUnauthorized)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+			return
+		}
+
+		role, err := jwtService.GetRoleByToken(authHeader)
+		if err != nil {
+			res := response.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			res.Code = string(apperror.CodeUnauthorized)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
 			return
 		}
 
 		ctx.Set("Authorization", authHeader)
 		ctx.Set("user_id", userID)
+		ctx.Set("role", role)
 		ctx.Next()
 	}
 }
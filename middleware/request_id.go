@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// RequestID tags every request with an ID, reusing one sent by a proxy when it
+// looks sane. The ID is stored on the context for logger.FromContext and
+// echoed back in the response header.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(logger.RequestIDKey, requestID)
+		ctx.Header(requestIDHeader, requestID)
+		ctx.Next()
+	}
+}
+
+// RequestLogger writes one access log line per request once it has been
+// handled, when the user ID from Authentication is known as well.
+func RequestLogger(log *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		fields := []zap.Field{
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.Int("status", ctx.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("client_ip", ctx.ClientIP()),
+		}
+		if len(ctx.Errors) > 0 {
+			fields = append(fields, zap.String("errors", ctx.Errors.String()))
+		}
+
+		reqLog := logger.FromContext(ctx, log)
+		switch status := ctx.Writer.Status(); {
+		case status >= 500:
+			reqLog.Error("request", fields...)
+		case status >= 400:
+			reqLog.Warn("request", fields...)
+		default:
+			reqLog.Info("request", fields...)
+		}
+	}
+}
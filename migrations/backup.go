@@ -0,0 +1,384 @@
+package migrations
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	backupFormatVersion = 1
+	backupManifestName  = "manifest.json"
+	backupUploadsDir    = "uploads"
+	backupBatchSize     = 500
+)
+
+type (
+	// BackupManifest is the first entry of every archive. It lists each data
+	// and upload file with its checksum so a restore can verify the archive
+	// before touching the database.
+	BackupManifest struct {
+		FormatVersion int               `json:"format_version"`
+		SchemaVersion int64             `json:"schema_version"`
+		CreatedAt     time.Time         `json:"created_at"`
+		Tables        []BackupTableInfo `json:"tables"`
+		Files         []BackupFileInfo  `json:"files"`
+		MissingFiles  []string          `json:"missing_files,omitempty"`
+	}
+
+	BackupTableInfo struct {
+		Name   string `json:"name"`
+		File   string `json:"file"`
+		Rows   int64  `json:"rows"`
+		SHA256 string `json:"sha256"`
+	}
+
+	BackupFileInfo struct {
+		File   string `json:"file"`
+		Size   int64  `json:"size"`
+		SHA256 string `json:"sha256"`
+	}
+
+	// backupTable dumps and loads one table as NDJSON of its entity.
+	backupTable struct {
+		name  string
+		count func(tx *gorm.DB) (int64, error)
+		dump  func(tx *gorm.DB, w io.Writer) (int64, error)
+		load  func(tx *gorm.DB, r io.Reader) (int64, error)
+	}
+)
+
+// backupTables is in dependency order: every table only references tables
+// listed before it, so a restore can insert them top to bottom.
+var backupTables = []backupTable{
+	tableOf[entity.User]("users"),
+	tableOf[entity.ScoringRule]("scoring_rules"),
+	tableOf[entity.Claim]("claims"),
+	tableOf[entity.Vote]("votes"),
+	tableOf[entity.PlayerStat]("player_stats"),
+	tableOf[entity.RatingHistory]("rating_histories"),
+	tableOf[entity.Achievement]("achievements"),
+	tableOf[entity.UserAchievement]("user_achievements"),
+	tableOf[entity.ClaimRevision]("claim_revisions"),
+	tableOf[entity.Notification]("notifications"),
+	tableOf[entity.Dispute]("disputes"),
+	tableOf[entity.DisputeVote]("dispute_votes"),
+	tableOf[entity.ClaimComment]("claim_comments"),
+	tableOf[entity.Reaction]("reactions"),
+}
+
+func tableOf[T any](name string) backupTable {
+	return backupTable{
+		name: name,
+		count: func(tx *gorm.DB) (int64, error) {
+			var count int64
+			err := tx.Unscoped().Model(new(T)).Count(&count).Error
+			return count, err
+		},
+		dump: func(tx *gorm.DB, w io.Writer) (int64, error) {
+			// Soft deleted rows are kept so the trash survives a move too.
+			rows, err := tx.Unscoped().Model(new(T)).Order(`"created_at" ASC`).Rows()
+			if err != nil {
+				return 0, err
+			}
+			defer rows.Close()
+
+			encoder := json.NewEncoder(w)
+			var count int64
+			for rows.Next() {
+				var row T
+				if err := tx.ScanRows(rows, &row); err != nil {
+					return count, err
+				}
+				if err := encoder.Encode(&row); err != nil {
+					return count, err
+				}
+				count++
+			}
+
+			return count, rows.Err()
+		},
+		load: func(tx *gorm.DB, r io.Reader) (int64, error) {
+			rowSchema, err := schema.Parse(new(T), &sync.Map{}, tx.NamingStrategy)
+			if err != nil {
+				return 0, err
+			}
+
+			decoder := json.NewDecoder(r)
+			batch := make([]map[string]interface{}, 0, backupBatchSize)
+			var count int64
+			flush := func() error {
+				if len(batch) == 0 {
+					return nil
+				}
+				if err := tx.Table(name).Create(&batch).Error; err != nil {
+					return err
+				}
+				count += int64(len(batch))
+				batch = batch[:0]
+				return nil
+			}
+
+			for {
+				row := new(T)
+				err := decoder.Decode(row)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return count, err
+				}
+
+				batch = append(batch, columnValues(tx.Statement.Context, rowSchema, row))
+				if len(batch) == backupBatchSize {
+					if err := flush(); err != nil {
+						return count, err
+					}
+				}
+			}
+
+			return count, flush()
+		},
+	}
+}
+
+// columnValues maps every column of row to its field's value as archived.
+// Rows are restored from these maps rather than the entity itself: GORM swaps
+// the zero value of a field with a default tag for that default on create,
+// which would bring every deactivated user back as active. Going through a
+// plain map also skips BeforeCreate, so the archived IDs are kept.
+func columnValues(ctx context.Context, rowSchema *schema.Schema, row interface{}) map[string]interface{} {
+	rv := reflect.Indirect(reflect.ValueOf(row))
+
+	values := make(map[string]interface{}, len(rowSchema.DBNames))
+	for _, name := range rowSchema.DBNames {
+		values[name], _ = rowSchema.FieldsByDBName[name].ValueOf(ctx, rv)
+	}
+
+	return values
+}
+
+// Backup writes every table and the uploads referenced by claims and avatars
+// into a gzipped tar archive at filePath.
+func Backup(db *gorm.DB, filePath string) (*BackupManifest, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	manifest := &BackupManifest{
+		FormatVersion: backupFormatVersion,
+		CreatedAt:     time.Now(),
+	}
+	for version := range applied {
+		if version > manifest.SchemaVersion {
+			manifest.SchemaVersion = version
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "backup-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Tables are dumped to temporary files inside one read-only transaction,
+	// so the snapshot is consistent and the checksums are known up front. The
+	// uploads are listed from the same snapshot.
+	var uploads []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ READ ONLY").Error; err != nil {
+			return err
+		}
+
+		for _, table := range backupTables {
+			info, err := dumpTable(tx, table, tmpDir)
+			if err != nil {
+				return fmt.Errorf("failed to dump %s: %w", table.name, err)
+			}
+			manifest.Tables = append(manifest.Tables, *info)
+		}
+
+		names, err := referencedUploads(tx)
+		if err != nil {
+			return fmt.Errorf("failed to list uploads: %w", err)
+		}
+		uploads = names
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range uploads {
+		sum, size, err := fileChecksum(filepath.Join(backupUploadsDir, name))
+		if os.IsNotExist(err) {
+			manifest.MissingFiles = append(manifest.MissingFiles, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, BackupFileInfo{File: path.Join(backupUploadsDir, name), Size: size, SHA256: sum})
+	}
+
+	if err := writeBackupArchive(filePath, manifest, tmpDir); err != nil {
+		os.Remove(filePath)
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func dumpTable(tx *gorm.DB, table backupTable, dir string) (*BackupTableInfo, error) {
+	info := &BackupTableInfo{
+		Name: table.name,
+		File: path.Join("data", table.name+".ndjson"),
+	}
+
+	file, err := os.Create(filepath.Join(dir, table.name+".ndjson"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	buffered := bufio.NewWriter(io.MultiWriter(file, hash))
+	info.Rows, err = table.dump(tx, buffered)
+	if err != nil {
+		return nil, err
+	}
+	if err := buffered.Flush(); err != nil {
+		return nil, err
+	}
+
+	info.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return info, nil
+}
+
+// referencedUploads returns the names of files under ./uploads that claims or
+// avatars point to, in either the "uploads/x.png" or "/uploads/x.png" form.
+func referencedUploads(tx *gorm.DB) ([]string, error) {
+	var refs []string
+	if err := tx.Unscoped().Model(&entity.Claim{}).Where("screenshot_url LIKE ?", "%uploads/%").Pluck("screenshot_url", &refs).Error; err != nil {
+		return nil, err
+	}
+
+	var avatars []string
+	if err := tx.Unscoped().Model(&entity.User{}).Where("avatar_url LIKE ?", "%uploads/%").Pluck("avatar_url", &avatars).Error; err != nil {
+		return nil, err
+	}
+	refs = append(refs, avatars...)
+
+	seen := map[string]bool{}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		name := path.Base(ref[strings.LastIndex(ref, "uploads/")+len("uploads/"):])
+		if name == "." || name == "/" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func writeBackupArchive(filePath string, manifest *BackupManifest, tmpDir string) error {
+	out, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0644, Size: int64(len(manifestData)), ModTime: manifest.CreatedAt}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifestData); err != nil {
+		return err
+	}
+
+	for _, table := range manifest.Tables {
+		if err := addFileToArchive(tw, filepath.Join(tmpDir, table.Name+".ndjson"), table.File); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range manifest.Files {
+		if err := addFileToArchive(tw, filepath.FromSlash(file.File), file.File); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
+
+func addFileToArchive(tw *tar.Writer, src string, name string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: stat.Size(), ModTime: stat.ModTime()}); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+func fileChecksum(filePath string) (string, int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+)
+
+// archivedColumns runs row through the archive format and returns the column
+// values a restore would insert for it.
+func archivedColumns[T any](t *testing.T, row *T) map[string]interface{} {
+	t.Helper()
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		t.Fatalf("failed to encode row: %v", err)
+	}
+	restored := new(T)
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("failed to decode row: %v", err)
+	}
+
+	rowSchema, err := schema.Parse(new(T), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	return columnValues(context.Background(), rowSchema, restored)
+}
+
+func TestColumnValuesKeepZeroValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns func(t *testing.T) map[string]interface{}
+		want    map[string]interface{}
+	}{
+		{
+			name: "inactive user",
+			columns: func(t *testing.T) map[string]interface{} {
+				return archivedColumns(t, &entity.User{ID: uuid.New(), Username: "alice", IsActive: false, Role: "user"})
+			},
+			want: map[string]interface{}{"is_active": false, "role": "user"},
+		},
+		{
+			name: "active user",
+			columns: func(t *testing.T) map[string]interface{} {
+				return archivedColumns(t, &entity.User{ID: uuid.New(), Username: "bob", IsActive: true, Role: "admin"})
+			},
+			want: map[string]interface{}{"is_active": true, "role": "admin"},
+		},
+		{
+			name: "unread notification",
+			columns: func(t *testing.T) map[string]interface{} {
+				return archivedColumns(t, &entity.Notification{ID: uuid.New(), IsRead: false})
+			},
+			want: map[string]interface{}{"is_read": false},
+		},
+		{
+			name: "unscaled scoring rule",
+			columns: func(t *testing.T) map[string]interface{} {
+				return archivedColumns(t, &entity.ScoringRule{ID: uuid.New(), ScaleByTotalPlayer: false, BaselinePlayers: 0})
+			},
+			want: map[string]interface{}{"scale_by_total_player": false, "baseline_players": 0},
+		},
+		{
+			name: "unrated player stat",
+			columns: func(t *testing.T) map[string]interface{} {
+				return archivedColumns(t, &entity.PlayerStat{ID: uuid.New(), Rating: 0, TotalMatch: 0})
+			},
+			want: map[string]interface{}{"rating": 0.0, "total_match": 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := tt.columns(t)
+			for column, want := range tt.want {
+				got, ok := columns[column]
+				if !ok {
+					t.Errorf("column %s missing from %v", column, columns)
+					continue
+				}
+				if got != want {
+					t.Errorf("column %s = %v, want %v", column, got, want)
+				}
+			}
+		})
+	}
+}
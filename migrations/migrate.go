@@ -1,19 +1,201 @@
 package migrations
 
 import (
-	"github.com/Amierza/mc-kalak-backend/entity"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// migrationFilePattern matches files such as 000002_add_user_role.up.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type (
+	migration struct {
+		Version int64
+		Name    string
+		Up      string
+		Down    string
+	}
+
+	// SchemaMigration records one applied migration.
+	SchemaMigration struct {
+		Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+		Name      string    `gorm:"type:varchar(255);not null"`
+		AppliedAt time.Time `gorm:"not null"`
+	}
+
+	MigrationStatus struct {
+		Version   int64
+		Name      string
+		AppliedAt *time.Time
+	}
+)
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// loadMigrations reads the embedded SQL files in version order. Every
+// version needs both an up and a down file.
+func loadMigrations() ([]*migration, error) {
+	entries, err := fs.ReadDir(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*migration{}
+	for _, entry := range entries {
+		matches := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		content, err := sqlFiles.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: matches[2]}
+			byVersion[version] = m
+		}
+		if m.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has mismatched names %s and %s", version, m.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]*migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int64]SchemaMigration, error) {
+	if err := db.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+		"version" bigint PRIMARY KEY,
+		"name" varchar(255) NOT NULL,
+		"applied_at" timestamptz NOT NULL
+	)`).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigration
+	if err := db.Order(`"version" ASC`).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	return applied, nil
+}
+
+// Migrate applies every pending migration.
 func Migrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(
-		&entity.User{},
-		&entity.Claim{},
-		&entity.Vote{},
-		&entity.PlayerStat{},
-	); err != nil {
-		return err
+	_, err := MigrateUp(db, 0)
+	return err
+}
+
+// MigrateUp applies up to steps pending migrations in version order, or all
+// of them when steps is zero. Each migration runs in its own transaction
+// together with its schema_migrations row, so a failure leaves the database
+// at the last good version.
+func MigrateUp(db *gorm.DB, steps int) ([]string, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, m := range migrations {
+		if steps > 0 && len(done) >= steps {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s up: %w", m.Version, m.Name, err)
+		}
+
+		done = append(done, fmt.Sprintf("%06d_%s", m.Version, m.Name))
+	}
+
+	return done, nil
+}
+
+// GetMigrationStatus lists every known migration with when it was applied,
+// nil meaning pending.
+func GetMigrationStatus(db *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		status := MigrationStatus{
+			Version: m.Version,
+			Name:    m.Name,
+		}
+		if row, ok := applied[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
 	}
 
-	return nil
+	return statuses, nil
 }
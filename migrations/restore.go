@@ -0,0 +1,222 @@
+package migrations
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Restore loads an archive written by Backup into an empty database. The
+// archive is verified against its manifest first, then the schema is migrated
+// and every table is inserted in dependency order inside one transaction.
+// Upload files are written to ./uploads; none are left behind on failure.
+func Restore(db *gorm.DB, filePath string) (*BackupManifest, error) {
+	manifest, err := verifyBackupArchive(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := MigrateUp(db, 0); err != nil {
+		return nil, fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := applied[manifest.SchemaVersion]; manifest.SchemaVersion > 0 && !ok {
+		return nil, fmt.Errorf("backup needs schema version %d which this build does not have", manifest.SchemaVersion)
+	}
+
+	for _, table := range backupTables {
+		count, err := table.count(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table.name, err)
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("restore needs an empty database but %s has %d rows", table.name, count)
+		}
+	}
+
+	var written []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return readBackupArchive(filePath, func(header *tar.Header, r io.Reader) error {
+			if header.Name == backupManifestName {
+				return nil
+			}
+
+			if strings.HasPrefix(header.Name, backupUploadsDir+"/") {
+				created, err := restoreUpload(header.Name, r)
+				if created {
+					written = append(written, header.Name)
+				}
+				return err
+			}
+
+			return restoreTable(tx, manifest, header.Name, r)
+		})
+	})
+	if err != nil {
+		for _, name := range written {
+			os.Remove(filepath.FromSlash(name))
+		}
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func restoreTable(tx *gorm.DB, manifest *BackupManifest, name string, r io.Reader) error {
+	for _, info := range manifest.Tables {
+		if info.File != name {
+			continue
+		}
+
+		for _, table := range backupTables {
+			if table.name != info.Name {
+				continue
+			}
+
+			rows, err := table.load(tx, bufio.NewReader(r))
+			if err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table.name, err)
+			}
+			if rows != info.Rows {
+				return fmt.Errorf("restored %d rows into %s, manifest lists %d", rows, table.name, info.Rows)
+			}
+
+			return nil
+		}
+
+		return fmt.Errorf("unknown table %s in backup", info.Name)
+	}
+
+	return fmt.Errorf("unexpected file %s in backup", name)
+}
+
+// restoreUpload writes one upload file. Existing files are never overwritten.
+func restoreUpload(name string, r io.Reader) (bool, error) {
+	target := filepath.Join(backupUploadsDir, path.Base(name))
+	if _, err := os.Stat(target); err == nil {
+		return false, fmt.Errorf("upload %s already exists", target)
+	}
+
+	if err := os.MkdirAll(backupUploadsDir, 0755); err != nil {
+		return false, err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, r); err != nil {
+		return true, err
+	}
+
+	return true, file.Close()
+}
+
+// verifyBackupArchive reads the whole archive once and checks every entry
+// against the manifest checksums.
+func verifyBackupArchive(filePath string) (*BackupManifest, error) {
+	var manifest *BackupManifest
+	expected := map[string]string{}
+	seen := map[string]bool{}
+
+	err := readBackupArchive(filePath, func(header *tar.Header, r io.Reader) error {
+		if manifest == nil {
+			if header.Name != backupManifestName {
+				return fmt.Errorf("backup must start with %s, found %s", backupManifestName, header.Name)
+			}
+
+			manifest = &BackupManifest{}
+			if err := json.NewDecoder(r).Decode(manifest); err != nil {
+				return fmt.Errorf("failed to read manifest: %w", err)
+			}
+			if manifest.FormatVersion != backupFormatVersion {
+				return fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+			}
+
+			for _, table := range manifest.Tables {
+				expected[table.File] = table.SHA256
+			}
+			for _, file := range manifest.Files {
+				expected[file.File] = file.SHA256
+			}
+			return nil
+		}
+
+		sum, ok := expected[header.Name]
+		if !ok {
+			return fmt.Errorf("unexpected file %s in backup", header.Name)
+		}
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, r); err != nil {
+			return err
+		}
+		if hex.EncodeToString(hash.Sum(nil)) != sum {
+			return fmt.Errorf("checksum mismatch for %s", header.Name)
+		}
+
+		seen[header.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, errors.New("backup is empty")
+	}
+
+	for name := range expected {
+		if !seen[name] {
+			return nil, fmt.Errorf("backup is missing %s", name)
+		}
+	}
+
+	return manifest, nil
+}
+
+func readBackupArchive(filePath string, fn func(header *tar.Header, r io.Reader) error) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		if err := fn(header, tr); err != nil {
+			return err
+		}
+	}
+}
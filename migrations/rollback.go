@@ -1,23 +1,52 @@
 package migrations
 
 import (
-	"github.com/Amierza/mc-kalak-backend/entity"
+	"fmt"
+
 	"gorm.io/gorm"
 )
 
+// Rollback reverts only the most recently applied migration.
 func Rollback(db *gorm.DB) error {
-	tables := []interface{}{
-		&entity.PlayerStat{},
-		&entity.Vote{},
-		&entity.Claim{},
-		&entity.User{},
+	_, err := MigrateDown(db, 1)
+	return err
+}
+
+// MigrateDown reverts the latest steps applied migrations, newest first.
+func MigrateDown(db *gorm.DB, steps int) ([]string, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("migrate down needs a positive number of steps")
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, table := range tables {
-		if err := db.Migrator().DropTable(table); err != nil {
-			return err
+	var done []string
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
 		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s down: %w", m.Version, m.Name, err)
+		}
+
+		done = append(done, fmt.Sprintf("%06d_%s", m.Version, m.Name))
 	}
 
-	return nil
+	return done, nil
 }
@@ -1,15 +1,244 @@
 package migrations
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/constants"
 	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/helper"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/Amierza/mc-kalak-backend/service"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
-func Seed(db *gorm.DB) error {
-	err := SeedFromJSON[entity.User](db, "./migrations/json/users.json", entity.User{}, "Email")
+const seedDir = "./migrations/json"
+
+type (
+	userSeed struct {
+		ID        uuid.UUID `json:"id"`
+		Username  string    `json:"username"`
+		Password  string    `json:"password"`
+		AvatarURL string    `json:"avatar_url"`
+		Role      string    `json:"role"`
+	}
+
+	scoringRuleSeed struct {
+		Version            int    `json:"version"`
+		KingPoints         int    `json:"king_points"`
+		KongPoints         int    `json:"kong_points"`
+		NgokPoints         int    `json:"ngok_points"`
+		ScaleByTotalPlayer bool   `json:"scale_by_total_player"`
+		BaselinePlayers    int    `json:"baseline_players"`
+		CreatedBy          string `json:"created_by"`
+	}
+
+	// Claims and votes refer to users by username so they still line up when
+	// a user already existed with a different ID.
+	claimSeed struct {
+		ID            uuid.UUID `json:"id"`
+		Event         string    `json:"event"`
+		Status        string    `json:"status"`
+		MatchDate     string    `json:"match_date"`
+		TotalPlayer   int       `json:"total_player"`
+		ScreenshotURL string    `json:"screenshot_url"`
+		ClaimedPlayer string    `json:"claimed_player"`
+		Reporter      string    `json:"reporter"`
+	}
+
+	voteSeed struct {
+		ClaimID uuid.UUID `json:"claim_id"`
+		Voter   string    `json:"voter"`
+		Type    string    `json:"type"`
+	}
+)
+
+// Seed upserts the demo data set and rebuilds player stats from it. Running it
+// again refreshes the seeded rows without duplicating them.
+func Seed(db *gorm.DB, appLogger *zap.Logger) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := SeedFromJSON(tx, seedDir+"/users.json", mapUserSeed, []string{"username"}, "avatar_url", "role", "is_active", "updated_at"); err != nil {
+			return err
+		}
+
+		if err := SeedFromJSON(tx, seedDir+"/scoring_rules.json", mapScoringRuleSeed, []string{"version"}); err != nil {
+			return err
+		}
+
+		if err := SeedFromJSON(tx, seedDir+"/claims.json", mapClaimSeed, []string{"id"}, "event", "status", "match_date", "total_player", "screenshot_url", "claimed_player_id", "reporter_id", "deleted_at", "updated_at"); err != nil {
+			return err
+		}
+
+		if err := SeedFromJSON(tx, seedDir+"/votes.json", mapVoteSeed, []string{"claim_id", "voter_id"}, "type", "deleted_at", "updated_at"); err != nil {
+			return err
+		}
+
+		return syncClaimVoteCounts(tx)
+	})
 	if err != nil {
 		return err
 	}
 
+	statService := service.NewStatService(db, repository.NewPlayerStatRepository(db), repository.NewScoringRuleRepository(db), repository.NewClaimRepository(db), repository.NewRatingHistoryRepository(db), repository.NewUserRepository(db), repository.NewVoterStatRepository(db), appLogger)
+	if _, err := statService.RecomputeAll(context.Background(), false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func mapUserSeed(tx *gorm.DB, record userSeed) (*entity.User, error) {
+	if record.Username == "" || record.Password == "" {
+		return nil, fmt.Errorf("username and password are required")
+	}
+
+	hashedPassword, err := helper.HashPassword(record.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password for %s: %w", record.Username, err)
+	}
+
+	role := record.Role
+	if role == "" {
+		role = constants.ENUM_ROLE_USER
+	}
+	if role != constants.ENUM_ROLE_USER && role != constants.ENUM_ROLE_ADMIN {
+		return nil, fmt.Errorf("invalid role %s for %s", role, record.Username)
+	}
+
+	id := record.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+
+	return &entity.User{
+		ID:        id,
+		Username:  record.Username,
+		Password:  hashedPassword,
+		AvatarURL: record.AvatarURL,
+		IsActive:  true,
+		Role:      role,
+	}, nil
+}
+
+func mapScoringRuleSeed(tx *gorm.DB, record scoringRuleSeed) (*entity.ScoringRule, error) {
+	if record.Version <= 0 {
+		return nil, fmt.Errorf("version must be positive")
+	}
+
+	rule := &entity.ScoringRule{
+		Version:            record.Version,
+		KingPoints:         record.KingPoints,
+		KongPoints:         record.KongPoints,
+		NgokPoints:         record.NgokPoints,
+		ScaleByTotalPlayer: record.ScaleByTotalPlayer,
+		BaselinePlayers:    record.BaselinePlayers,
+	}
+	if rule.BaselinePlayers <= 0 {
+		rule.BaselinePlayers = entity.DefaultScoringRule().BaselinePlayers
+	}
+
+	if record.CreatedBy != "" {
+		createdByID, err := seedUserID(tx, record.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		rule.CreatedByID = &createdByID
+	}
+
+	return rule, nil
+}
+
+func mapClaimSeed(tx *gorm.DB, record claimSeed) (*entity.Claim, error) {
+	if record.ID == uuid.Nil {
+		return nil, fmt.Errorf("claim id is required to keep seeding idempotent")
+	}
+
+	event := entity.ClaimEvent(record.Event)
+	if event != entity.EventKing && event != entity.EventKong && event != entity.EventNgok {
+		return nil, fmt.Errorf("invalid event %s", record.Event)
+	}
+
+	status := entity.ClaimStatus(record.Status)
+	if status == "" {
+		status = entity.StatusPending
+	}
+	if status != entity.StatusPending && status != entity.StatusFinalApproved && status != entity.StatusFinalRejected {
+		return nil, fmt.Errorf("invalid status %s", record.Status)
+	}
+
+	matchDate, err := time.Parse("2006-01-02", record.MatchDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid match date %s: %w", record.MatchDate, err)
+	}
+
+	if record.TotalPlayer <= 0 {
+		return nil, fmt.Errorf("total player must be positive")
+	}
+
+	claimedPlayerID, err := seedUserID(tx, record.ClaimedPlayer)
+	if err != nil {
+		return nil, err
+	}
+
+	reporterID, err := seedUserID(tx, record.Reporter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Claim{
+		ID:              record.ID,
+		Event:           event,
+		Status:          status,
+		MatchDate:       matchDate,
+		TotalPlayer:     record.TotalPlayer,
+		ScreenshotURL:   record.ScreenshotURL,
+		ClaimedPlayerID: claimedPlayerID,
+		ReporterID:      reporterID,
+	}, nil
+}
+
+func mapVoteSeed(tx *gorm.DB, record voteSeed) (*entity.Vote, error) {
+	voteType := entity.VoteType(record.Type)
+	if voteType != entity.VoteApprove && voteType != entity.VoteReject {
+		return nil, fmt.Errorf("invalid vote type %s", record.Type)
+	}
+
+	voterID, err := seedUserID(tx, record.Voter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Vote{
+		ClaimID: record.ClaimID,
+		VoterID: voterID,
+		Type:    voteType,
+	}, nil
+}
+
+// seedUserID resolves a username from a seed file to the stored user ID.
+func seedUserID(tx *gorm.DB, username string) (uuid.UUID, error) {
+	var user entity.User
+	if err := tx.Select("id").Where("username = ?", username).Take(&user).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find seeded user %s: %w", username, err)
+	}
+
+	return user.ID, nil
+}
+
+// syncClaimVoteCounts recounts approve and reject votes so seeded claims agree
+// with their seeded votes.
+func syncClaimVoteCounts(tx *gorm.DB) error {
+	err := tx.Exec(`
+		UPDATE claims SET
+			approve_count = (SELECT COUNT(*) FROM votes WHERE votes.claim_id = claims.id AND votes.type = ? AND votes.deleted_at IS NULL),
+			reject_count = (SELECT COUNT(*) FROM votes WHERE votes.claim_id = claims.id AND votes.type = ? AND votes.deleted_at IS NULL)
+		WHERE claims.deleted_at IS NULL`, entity.VoteApprove, entity.VoteReject).Error
+	if err != nil {
+		return fmt.Errorf("failed to sync claim vote counts: %w", err)
+	}
+
 	return nil
 }
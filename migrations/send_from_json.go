@@ -2,17 +2,21 @@ package migrations
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"os"
-	"reflect"
 
-	"github.com/Amierza/mc-kalak-backend/helper"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func SeedFromJSON[T any](db *gorm.DB, filePath string, model T, uniqueFields ...string) error {
+// SeedMapper turns one record of a seed file into the entity to store.
+type SeedMapper[R any, T any] func(tx *gorm.DB, record R) (*T, error)
+
+// SeedFromJSON reads a JSON array of R from filePath, maps every record with
+// mapper and upserts the result on conflictColumns. Rows that already exist
+// only get updateColumns refreshed; without updateColumns they are left as is.
+func SeedFromJSON[R any, T any](tx *gorm.DB, filePath string, mapper SeedMapper[R, T], conflictColumns []string, updateColumns ...string) error {
 	jsonFile, err := os.Open(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to open file %s: %w", filePath, err)
@@ -24,40 +28,29 @@ func SeedFromJSON[T any](db *gorm.DB, filePath string, model T, uniqueFields ...
 		return fmt.Errorf("failed to read JSON data: %w", err)
 	}
 
-	var listData []T
-	if err := json.Unmarshal(jsonData, &listData); err != nil {
+	var records []R
+	if err := json.Unmarshal(jsonData, &records); err != nil {
 		return fmt.Errorf("failed to unmarshal JSON data: %w", err)
 	}
 
-	if err := db.AutoMigrate(&model); err != nil {
-		return fmt.Errorf("failed to migrate model: %w", err)
+	columns := make([]clause.Column, 0, len(conflictColumns))
+	for _, name := range conflictColumns {
+		columns = append(columns, clause.Column{Name: name})
 	}
 
-	for _, data := range listData {
-		query := db.Model(&model)
-
-		if len(uniqueFields) > 0 {
-			for _, field := range uniqueFields {
-				val := reflect.ValueOf(data)
-				if val.Kind() == reflect.Ptr {
-					val = val.Elem()
-				}
-				f := val.FieldByName(field)
-				if !f.IsValid() {
-					return fmt.Errorf("field %s not found in model", field)
-				}
-				query = query.Where(fmt.Sprintf("%s = ?", helper.SnakeCase(field)), f.Interface())
-			}
-		}
+	onConflict := clause.OnConflict{Columns: columns, DoNothing: true}
+	if len(updateColumns) > 0 {
+		onConflict = clause.OnConflict{Columns: columns, DoUpdates: clause.AssignmentColumns(updateColumns)}
+	}
 
-		var existing T
-		err := query.First(&existing).Error
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			continue
+	for i, record := range records {
+		data, err := mapper(tx, record)
+		if err != nil {
+			return fmt.Errorf("failed to map record %d of %s: %w", i, filePath, err)
 		}
 
-		if err := db.Create(&data).Error; err != nil {
-			return fmt.Errorf("failed to insert data: %w", err)
+		if err := tx.Omit(clause.Associations).Clauses(onConflict).Create(data).Error; err != nil {
+			return fmt.Errorf("failed to upsert record %d of %s: %w", i, filePath, err)
 		}
 	}
 
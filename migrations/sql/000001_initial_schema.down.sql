@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "player_stats";
+DROP TABLE IF EXISTS "votes";
+DROP TABLE IF EXISTS "claims";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,70 @@
+-- Baseline schema. Every statement is guarded so databases previously
+-- created by AutoMigrate can adopt versioned migrations without changes.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid,
+    "username" text NOT NULL,
+    "password" text NOT NULL,
+    "avatar_url" text,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+
+CREATE TABLE IF NOT EXISTS "claims" (
+    "id" uuid,
+    "event" varchar(10) NOT NULL,
+    "status" varchar(20) DEFAULT 'PENDING',
+    "match_date" date NOT NULL,
+    "total_player" bigint NOT NULL,
+    "screenshot_url" text NOT NULL,
+    "approve_count" bigint DEFAULT 0,
+    "reject_count" bigint DEFAULT 0,
+    "claimed_player_id" uuid NOT NULL,
+    "reporter_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_claimed_events" FOREIGN KEY ("claimed_player_id") REFERENCES "users"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_reported_claims" FOREIGN KEY ("reporter_id") REFERENCES "users"("id") ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_claims_reporter_id" ON "claims" ("reporter_id");
+CREATE INDEX IF NOT EXISTS "idx_claims_claimed_player_id" ON "claims" ("claimed_player_id");
+CREATE INDEX IF NOT EXISTS "idx_claims_match_date" ON "claims" ("match_date");
+
+CREATE TABLE IF NOT EXISTS "votes" (
+    "id" uuid,
+    "claim_id" uuid NOT NULL,
+    "voter_id" uuid NOT NULL,
+    "type" varchar(10) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_claims_votes" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_users_votes" FOREIGN KEY ("voter_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_votes_voter_id" ON "votes" ("voter_id");
+CREATE INDEX IF NOT EXISTS "idx_votes_claim_id" ON "votes" ("claim_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_claim_voter" ON "votes" ("claim_id", "voter_id");
+
+CREATE TABLE IF NOT EXISTS "player_stats" (
+    "id" uuid,
+    "total_match" bigint DEFAULT 0,
+    "king_count" bigint DEFAULT 0,
+    "kong_count" bigint DEFAULT 0,
+    "ngok_count" bigint DEFAULT 0,
+    "score" bigint DEFAULT 0,
+    "win_rate" decimal DEFAULT 0,
+    "player_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_player_stats_player" FOREIGN KEY ("player_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_player_stats_player_id" ON "player_stats" ("player_id");
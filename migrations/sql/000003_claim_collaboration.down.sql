@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "reactions";
+DROP TABLE IF EXISTS "claim_comments";
+DROP TABLE IF EXISTS "dispute_votes";
+DROP TABLE IF EXISTS "disputes";
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "claim_revisions";
@@ -0,0 +1,104 @@
+-- Revisions, notifications, disputes, comments and reactions on claims.
+
+CREATE TABLE IF NOT EXISTS "claim_revisions" (
+    "id" uuid,
+    "action" varchar(20) NOT NULL,
+    "changes" jsonb NOT NULL DEFAULT '[]',
+    "claim_id" uuid NOT NULL,
+    "actor_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_claim_revisions_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_claim_revisions_actor" FOREIGN KEY ("actor_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_claim_revisions_actor_id" ON "claim_revisions" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_claim_revisions_claim_id" ON "claim_revisions" ("claim_id");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" uuid,
+    "type" varchar(30) NOT NULL,
+    "message" text NOT NULL,
+    "is_read" boolean DEFAULT false,
+    "user_id" uuid NOT NULL,
+    "claim_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_notifications_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_notifications_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_claim_id" ON "notifications" ("claim_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "disputes" (
+    "id" uuid,
+    "reason" text NOT NULL,
+    "status" varchar(20) DEFAULT 'OPEN',
+    "previous_status" varchar(20) NOT NULL,
+    "resolved_at" timestamptz,
+    "uphold_count" bigint DEFAULT 0,
+    "dismiss_count" bigint DEFAULT 0,
+    "claim_id" uuid NOT NULL,
+    "appellant_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_disputes_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_disputes_appellant" FOREIGN KEY ("appellant_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_disputes_appellant_id" ON "disputes" ("appellant_id");
+CREATE INDEX IF NOT EXISTS "idx_disputes_claim_id" ON "disputes" ("claim_id");
+
+CREATE TABLE IF NOT EXISTS "dispute_votes" (
+    "id" uuid,
+    "dispute_id" uuid NOT NULL,
+    "voter_id" uuid NOT NULL,
+    "type" varchar(10) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_dispute_votes_voter" FOREIGN KEY ("voter_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_disputes_votes" FOREIGN KEY ("dispute_id") REFERENCES "disputes"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_dispute_votes_voter_id" ON "dispute_votes" ("voter_id");
+CREATE INDEX IF NOT EXISTS "idx_dispute_votes_dispute_id" ON "dispute_votes" ("dispute_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_dispute_voter" ON "dispute_votes" ("dispute_id", "voter_id");
+
+CREATE TABLE IF NOT EXISTS "claim_comments" (
+    "id" uuid,
+    "content" text NOT NULL,
+    "claim_id" uuid NOT NULL,
+    "author_id" uuid NOT NULL,
+    "parent_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_claim_comments_author" FOREIGN KEY ("author_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_claim_comments_replies" FOREIGN KEY ("parent_id") REFERENCES "claim_comments"("id"),
+    CONSTRAINT "fk_claim_comments_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_claim_comments_parent_id" ON "claim_comments" ("parent_id");
+CREATE INDEX IF NOT EXISTS "idx_claim_comments_author_id" ON "claim_comments" ("author_id");
+CREATE INDEX IF NOT EXISTS "idx_claim_comments_claim_id" ON "claim_comments" ("claim_id");
+
+CREATE TABLE IF NOT EXISTS "reactions" (
+    "id" uuid,
+    "emoji" varchar(32) NOT NULL,
+    "claim_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_reactions_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_reactions_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_reactions_user_id" ON "reactions" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_reactions_claim_id" ON "reactions" ("claim_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_claim_user_emoji" ON "reactions" ("emoji", "claim_id", "user_id");
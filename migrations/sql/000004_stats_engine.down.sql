@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "user_achievements";
+DROP TABLE IF EXISTS "achievements";
+DROP TABLE IF EXISTS "rating_histories";
+
+ALTER TABLE "player_stats"
+    DROP COLUMN IF EXISTS "longest_ngok_streak",
+    DROP COLUMN IF EXISTS "current_ngok_streak",
+    DROP COLUMN IF EXISTS "longest_kong_streak",
+    DROP COLUMN IF EXISTS "current_kong_streak",
+    DROP COLUMN IF EXISTS "longest_king_streak",
+    DROP COLUMN IF EXISTS "current_king_streak",
+    DROP COLUMN IF EXISTS "rating",
+    DROP COLUMN IF EXISTS "scoring_version";
+
+DROP TABLE IF EXISTS "scoring_rules";
@@ -0,0 +1,76 @@
+-- Scoring rules, ratings, streaks and achievements.
+
+CREATE TABLE IF NOT EXISTS "scoring_rules" (
+    "id" uuid,
+    "version" bigint NOT NULL,
+    "king_points" bigint NOT NULL,
+    "kong_points" bigint NOT NULL,
+    "ngok_points" bigint NOT NULL,
+    "scale_by_total_player" boolean DEFAULT false,
+    "baseline_players" bigint DEFAULT 2,
+    "created_by_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_scoring_rules_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_scoring_rules_created_by_id" ON "scoring_rules" ("created_by_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_scoring_rules_version" ON "scoring_rules" ("version");
+
+ALTER TABLE "player_stats"
+    ADD COLUMN IF NOT EXISTS "scoring_version" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "rating" decimal DEFAULT 1500,
+    ADD COLUMN IF NOT EXISTS "current_king_streak" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "longest_king_streak" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "current_kong_streak" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "longest_kong_streak" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "current_ngok_streak" bigint DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "longest_ngok_streak" bigint DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS "rating_histories" (
+    "id" uuid,
+    "match_date" date NOT NULL,
+    "rating_before" decimal NOT NULL,
+    "rating_after" decimal NOT NULL,
+    "delta" decimal NOT NULL,
+    "opponents" bigint NOT NULL,
+    "player_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_rating_histories_player" FOREIGN KEY ("player_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_rating_histories_player_id" ON "rating_histories" ("player_id");
+CREATE INDEX IF NOT EXISTS "idx_rating_histories_match_date" ON "rating_histories" ("match_date");
+
+CREATE TABLE IF NOT EXISTS "achievements" (
+    "id" uuid,
+    "code" varchar(50) NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "description" text,
+    "icon" varchar(32),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_achievements_code" ON "achievements" ("code");
+
+CREATE TABLE IF NOT EXISTS "user_achievements" (
+    "id" uuid,
+    "user_id" uuid NOT NULL,
+    "achievement_id" uuid NOT NULL,
+    "claim_id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_user_achievements_user" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_user_achievements_achievement" FOREIGN KEY ("achievement_id") REFERENCES "achievements"("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_user_achievements_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_user_achievements_claim_id" ON "user_achievements" ("claim_id");
+CREATE INDEX IF NOT EXISTS "idx_user_achievements_user_id" ON "user_achievements" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_achievement" ON "user_achievements" ("user_id", "achievement_id");
@@ -0,0 +1 @@
+ALTER TABLE "claims" ADD COLUMN IF NOT EXISTS "is_imported" boolean DEFAULT false;
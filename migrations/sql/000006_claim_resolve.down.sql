@@ -0,0 +1 @@
+ALTER TABLE "claim_revisions" DROP COLUMN IF EXISTS "reason";
@@ -0,0 +1 @@
+ALTER TABLE "claim_revisions" ADD COLUMN IF NOT EXISTS "reason" text;
@@ -0,0 +1,2 @@
+ALTER TABLE "claim_revisions" DROP CONSTRAINT IF EXISTS "fk_claim_revisions_actor";
+ALTER TABLE "claim_revisions" ADD CONSTRAINT "fk_claim_revisions_actor" FOREIGN KEY ("actor_id") REFERENCES "users"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
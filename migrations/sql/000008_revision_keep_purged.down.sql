@@ -0,0 +1,7 @@
+DELETE FROM "claim_revisions" WHERE "claim_id" IS NULL;
+ALTER TABLE "claim_revisions" DROP CONSTRAINT IF EXISTS "fk_claim_revisions_claim";
+ALTER TABLE "claim_revisions" ADD CONSTRAINT "fk_claim_revisions_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "claim_revisions" ALTER COLUMN "claim_id" SET NOT NULL;
+
+DROP INDEX IF EXISTS "idx_claim_revisions_subject_claim_id";
+ALTER TABLE "claim_revisions" DROP COLUMN IF EXISTS "subject_claim_id";
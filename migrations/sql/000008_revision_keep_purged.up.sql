@@ -0,0 +1,8 @@
+ALTER TABLE "claim_revisions" ADD COLUMN IF NOT EXISTS "subject_claim_id" uuid;
+UPDATE "claim_revisions" SET "subject_claim_id" = "claim_id" WHERE "subject_claim_id" IS NULL;
+ALTER TABLE "claim_revisions" ALTER COLUMN "subject_claim_id" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "idx_claim_revisions_subject_claim_id" ON "claim_revisions" ("subject_claim_id");
+
+ALTER TABLE "claim_revisions" ALTER COLUMN "claim_id" DROP NOT NULL;
+ALTER TABLE "claim_revisions" DROP CONSTRAINT IF EXISTS "fk_claim_revisions_claim";
+ALTER TABLE "claim_revisions" ADD CONSTRAINT "fk_claim_revisions_claim" FOREIGN KEY ("claim_id") REFERENCES "claims"("id") ON DELETE SET NULL ON UPDATE CASCADE;
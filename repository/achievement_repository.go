@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IAchievementRepository interface {
+		Sync(ctx context.Context, tx *gorm.DB, achievements []*entity.Achievement) ([]*entity.Achievement, error)
+		Award(ctx context.Context, tx *gorm.DB, userAchievement *entity.UserAchievement) (bool, error)
+		GetAllByUserID(ctx context.Context, tx *gorm.DB, userID *uuid.UUID) ([]*entity.UserAchievement, error)
+	}
+
+	achievementRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAchievementRepository(db *gorm.DB) *achievementRepository {
+	return &achievementRepository{
+		db: db,
+	}
+}
+
+// Sync upserts the catalog by code and returns the stored rows, whose IDs are
+// the ones awards must reference.
+func (ar *achievementRepository) Sync(ctx context.Context, tx *gorm.DB, achievements []*entity.Achievement) ([]*entity.Achievement, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	codes := make([]string, 0, len(achievements))
+	for _, achievement := range achievements {
+		codes = append(codes, achievement.Code)
+	}
+
+	err := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "description", "icon", "updated_at"}),
+		}).
+		Create(&achievements).Error
+	if err != nil {
+		return []*entity.Achievement{}, err
+	}
+
+	var stored []*entity.Achievement
+	if err := tx.WithContext(ctx).Where("code IN ?", codes).Find(&stored).Error; err != nil {
+		return []*entity.Achievement{}, err
+	}
+
+	return stored, nil
+}
+
+// Award inserts the badge unless the user already has it and reports whether
+// it was newly awarded, which makes re-evaluating the same claim harmless.
+func (ar *achievementRepository) Award(ctx context.Context, tx *gorm.DB, userAchievement *entity.UserAchievement) (bool, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	result := tx.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&userAchievement)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (ar *achievementRepository) GetAllByUserID(ctx context.Context, tx *gorm.DB, userID *uuid.UUID) ([]*entity.UserAchievement, error) {
+	if tx == nil {
+		tx = ar.db
+	}
+
+	var userAchievements []*entity.UserAchievement
+	err := tx.WithContext(ctx).
+		Preload("Achievement").
+		Where("user_id = ?", userID).
+		Order(`"created_at" ASC`).
+		Find(&userAchievements).Error
+	if err != nil {
+		return []*entity.UserAchievement{}, err
+	}
+
+	return userAchievements, nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IClaimCommentRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, comment *entity.ClaimComment) error
+		GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.ClaimComment, error)
+		GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.ClaimComment, bool, error)
+		CountByClaimIDs(ctx context.Context, tx *gorm.DB, claimIDs []uuid.UUID) (map[uuid.UUID]int, error)
+		Update(ctx context.Context, tx *gorm.DB, comment *entity.ClaimComment) error
+		DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error
+	}
+
+	claimCommentRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewClaimCommentRepository(db *gorm.DB) *claimCommentRepository {
+	return &claimCommentRepository{
+		db: db,
+	}
+}
+
+func (ccr *claimCommentRepository) Create(ctx context.Context, tx *gorm.DB, comment *entity.ClaimComment) error {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	return tx.WithContext(ctx).Create(&comment).Error
+}
+
+func (ccr *claimCommentRepository) GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.ClaimComment, error) {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	var (
+		comments []*entity.ClaimComment
+		err      error
+	)
+
+	query := tx.WithContext(ctx).
+		Preload("Author").
+		Where("claim_id = ?", claimID).
+		Model(&entity.ClaimComment{})
+	if err := query.Order(`"created_at" ASC`).Find(&comments).Error; err != nil {
+		return []*entity.ClaimComment{}, err
+	}
+
+	return comments, err
+}
+
+func (ccr *claimCommentRepository) GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.ClaimComment, bool, error) {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	var comment *entity.ClaimComment
+	err := tx.WithContext(ctx).
+		Preload("Author").
+		Where("id = ?", &id).Take(&comment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.ClaimComment{}, false, nil
+	}
+	if err != nil {
+		return &entity.ClaimComment{}, false, err
+	}
+
+	return comment, true, nil
+}
+
+// CountByClaimIDs counts the comments a thread shows: replies hanging off a
+// deleted parent are left out.
+func (ccr *claimCommentRepository) CountByClaimIDs(ctx context.Context, tx *gorm.DB, claimIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	counts := make(map[uuid.UUID]int, len(claimIDs))
+	if len(claimIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ClaimID uuid.UUID
+		Count   int
+	}
+	err := tx.WithContext(ctx).
+		Model(&entity.ClaimComment{}).
+		Select("claim_comments.claim_id, COUNT(*) AS count").
+		Joins(`LEFT JOIN "claim_comments" AS "parent" ON "parent"."id" = "claim_comments"."parent_id"`).
+		Where(`"claim_comments"."claim_id" IN ? AND ("claim_comments"."parent_id" IS NULL OR "parent"."deleted_at" IS NULL)`, claimIDs).
+		Group("claim_comments.claim_id").
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.ClaimID] = row.Count
+	}
+
+	return counts, nil
+}
+
+func (ccr *claimCommentRepository) Update(ctx context.Context, tx *gorm.DB, comment *entity.ClaimComment) error {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.ClaimComment{}).
+		Where("id = ?", comment.ID).
+		Omit(clause.Associations).
+		Updates(&comment).Error
+}
+
+// DeleteByID soft deletes the comment together with every reply below it, so
+// no reply is left pointing at a deleted parent.
+func (ccr *claimCommentRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error {
+	if tx == nil {
+		tx = ccr.db
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ids := []uuid.UUID{*id}
+		for parentIDs := ids; len(parentIDs) > 0; {
+			var replyIDs []uuid.UUID
+			if err := tx.Model(&entity.ClaimComment{}).Where("parent_id IN ?", parentIDs).Pluck("id", &replyIDs).Error; err != nil {
+				return err
+			}
+
+			ids = append(ids, replyIDs...)
+			parentIDs = replyIDs
+		}
+
+		return tx.Where("id IN ?", ids).Delete(&entity.ClaimComment{}).Error
+	})
+}
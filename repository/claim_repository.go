@@ -4,21 +4,33 @@ import (
 	"context"
 	"errors"
 	"math"
+	"time"
 
 	"github.com/Amierza/mc-kalak-backend/dto"
 	"github.com/Amierza/mc-kalak-backend/entity"
 	"github.com/Amierza/mc-kalak-backend/response"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	IClaimRepository interface {
 		Create(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error
-		GetAllClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest) (dto.ClaimPaginationRepositoryResponse, error)
+		GetAllClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest, filter dto.ClaimFilterRequest) (dto.ClaimPaginationRepositoryResponse, error)
 		GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Claim, bool, error)
 		Update(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error
 		DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error
+		GetAllDeletedClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest) (dto.ClaimPaginationRepositoryResponse, error)
+		GetDeletedByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Claim, bool, error)
+		ExistsByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (bool, error)
+		RestoreByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error
+		PurgeByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error
+		GetAllFinalApproved(ctx context.Context, tx *gorm.DB) ([]*entity.Claim, error)
+		GetAllFinalApprovedByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]*entity.Claim, error)
+		GetAllFinalApprovedSharedByPlayerIDs(ctx context.Context, tx *gorm.DB, playerAID, playerBID *uuid.UUID) ([]*entity.Claim, error)
+		CreateBatch(ctx context.Context, tx *gorm.DB, claims []*entity.Claim) error
+		GetAllByMatchDateRange(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*entity.Claim, error)
 	}
 
 	claimRepository struct {
@@ -40,7 +52,7 @@ func (cr *claimRepository) Create(ctx context.Context, tx *gorm.DB, claim *entit
 	return tx.WithContext(ctx).Create(&claim).Error
 }
 
-func (cr *claimRepository) GetAllClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest) (dto.ClaimPaginationRepositoryResponse, error) {
+func (cr *claimRepository) GetAllClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest, filter dto.ClaimFilterRequest) (dto.ClaimPaginationRepositoryResponse, error) {
 	if tx == nil {
 		tx = cr.db
 	}
@@ -63,7 +75,8 @@ func (cr *claimRepository) GetAllClaimsWithPagination(ctx context.Context, tx *g
 		Preload("ClaimedPlayer").
 		Preload("Reporter").
 		Preload("Votes").
-		Model(&entity.Claim{})
+		Model(&entity.Claim{}).
+		Scopes(claimFilterScope(filter))
 
 	if err := query.Order(`"created_at" DESC`).Find(&claims).Error; err != nil {
 		return dto.ClaimPaginationRepositoryResponse{}, err
@@ -116,7 +129,14 @@ func (cr *claimRepository) Update(ctx context.Context, tx *gorm.DB, claim *entit
 		tx = cr.db
 	}
 
-	return tx.WithContext(ctx).Model(&entity.Claim{}).Where("id = ?", claim.ID).Updates(&claim).Error
+	// select every column so zero values such as reset vote counts are persisted,
+	// and skip associations so stale preloaded players don't overwrite the foreign keys
+	return tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Where("id = ?", claim.ID).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(&claim).Error
 }
 
 func (cr *claimRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error {
@@ -124,5 +144,252 @@ func (cr *claimRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid
 		tx = cr.db
 	}
 
-	return tx.WithContext(ctx).Where("id = ?", id).Delete(&entity.Claim{}).Error
+	// votes are soft-deleted together with their claim so a restore can bring them back
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&entity.Vote{}).Where("claim_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.Claim{}).Where("id = ?", id).Update("deleted_at", now).Error
+	})
+}
+
+func (cr *claimRepository) GetAllDeletedClaimsWithPagination(ctx context.Context, tx *gorm.DB, pagination response.PaginationRequest) (dto.ClaimPaginationRepositoryResponse, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var (
+		claims []*entity.Claim
+		err    error
+		count  int64
+	)
+
+	if pagination.PerPage == 0 {
+		pagination.PerPage = 10
+	}
+
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	query := tx.WithContext(ctx).
+		Unscoped().
+		Preload("ClaimedPlayer").
+		Preload("Reporter").
+		Model(&entity.Claim{}).
+		Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.ClaimPaginationRepositoryResponse{}, err
+	}
+
+	if err := query.Order(`"deleted_at" DESC`).Scopes(response.Paginate(pagination.Page, pagination.PerPage)).Find(&claims).Error; err != nil {
+		return dto.ClaimPaginationRepositoryResponse{}, err
+	}
+
+	totalPage := int64(math.Ceil(float64(count) / float64(pagination.PerPage)))
+
+	return dto.ClaimPaginationRepositoryResponse{
+		Claims: claims,
+		PaginationResponse: response.PaginationResponse{
+			Page:    pagination.Page,
+			PerPage: pagination.PerPage,
+			MaxPage: totalPage,
+			Count:   count,
+		},
+	}, err
+}
+
+func (cr *claimRepository) GetDeletedByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Claim, bool, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var claim *entity.Claim
+	err := tx.WithContext(ctx).
+		Unscoped().
+		Preload("ClaimedPlayer").
+		Preload("Reporter").
+		Where("id = ? AND deleted_at IS NOT NULL", &id).Take(&claim).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Claim{}, false, nil
+	}
+	if err != nil {
+		return &entity.Claim{}, false, err
+	}
+
+	return claim, true, nil
+}
+
+// ExistsByID reports whether the claim exists, counting trashed claims too.
+func (cr *claimRepository) ExistsByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (bool, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var count int64
+	err := tx.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Claim{}).
+		Where("id = ?", id).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (cr *claimRepository) RestoreByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&entity.Vote{}).Where("claim_id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Model(&entity.Claim{}).Where("id = ?", id).Update("deleted_at", nil).Error
+	})
+}
+
+func (cr *claimRepository) PurgeByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	// votes and notifications go with it through ON DELETE CASCADE, revisions
+	// stay behind with their claim_id cleared
+	return tx.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&entity.Claim{}).Error
+}
+
+// GetAllFinalApproved returns every counted claim in the order the matches
+// were played, which is the order ratings have to be replayed in.
+func (cr *claimRepository) GetAllFinalApproved(ctx context.Context, tx *gorm.DB) ([]*entity.Claim, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var claims []*entity.Claim
+	err := tx.WithContext(ctx).
+		Where("status = ?", entity.StatusFinalApproved).
+		Order(`"match_date" ASC, "created_at" ASC`).
+		Find(&claims).Error
+	if err != nil {
+		return []*entity.Claim{}, err
+	}
+
+	return claims, nil
+}
+
+func (cr *claimRepository) GetAllFinalApprovedByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]*entity.Claim, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var claims []*entity.Claim
+	err := tx.WithContext(ctx).
+		Where("claimed_player_id = ? AND status = ?", playerID, entity.StatusFinalApproved).
+		Order(`"match_date" ASC, "created_at" ASC`).
+		Find(&claims).Error
+	if err != nil {
+		return []*entity.Claim{}, err
+	}
+
+	return claims, nil
+}
+
+// GetAllFinalApprovedSharedByPlayerIDs returns both players' approved claims
+// from the matches where each of them has at least one, newest first. A match
+// is a match date plus screenshot, the same pair entity.Claim.MatchKey uses.
+func (cr *claimRepository) GetAllFinalApprovedSharedByPlayerIDs(ctx context.Context, tx *gorm.DB, playerAID, playerBID *uuid.UUID) ([]*entity.Claim, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	playerIDs := []uuid.UUID{*playerAID, *playerBID}
+	sharedMatches := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select("match_date, screenshot_url").
+		Where("status = ? AND claimed_player_id IN ?", entity.StatusFinalApproved, playerIDs).
+		Group("match_date, screenshot_url").
+		Having("COUNT(DISTINCT claimed_player_id) = ?", len(playerIDs))
+
+	var claims []*entity.Claim
+	err := tx.WithContext(ctx).
+		Where("status = ? AND claimed_player_id IN ? AND (match_date, screenshot_url) IN (?)", entity.StatusFinalApproved, playerIDs, sharedMatches).
+		Order(`"match_date" DESC, "screenshot_url" ASC, "created_at" ASC`).
+		Find(&claims).Error
+	if err != nil {
+		return []*entity.Claim{}, err
+	}
+
+	return claims, nil
+}
+
+// CreateBatch inserts all claims in one transaction, so an import either lands
+// completely or not at all.
+func (cr *claimRepository) CreateBatch(ctx context.Context, tx *gorm.DB, claims []*entity.Claim) error {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(claims, 100).Error
+	})
+}
+
+// GetAllByMatchDateRange loads only the player, event and match date of every
+// claim played between from and to inclusive, which is all an import needs to
+// spot rows that already exist.
+func (cr *claimRepository) GetAllByMatchDateRange(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*entity.Claim, error) {
+	if tx == nil {
+		tx = cr.db
+	}
+
+	var claims []*entity.Claim
+	err := tx.WithContext(ctx).
+		Select("claimed_player_id", "event", "match_date").
+		Where("match_date BETWEEN ? AND ?", from, to).
+		Find(&claims).Error
+	if err != nil {
+		return []*entity.Claim{}, err
+	}
+
+	return claims, nil
+}
+
+// claimFilterScope applies the claim listing filters. Columns are qualified so
+// the scope also works on queries that join users. Without a status filter,
+// cancelled claims are left out.
+func claimFilterScope(filter dto.ClaimFilterRequest) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Search != "" {
+			search := "%" + filter.Search + "%"
+			db = db.Where(`"claims"."claimed_player_id" IN (SELECT "id" FROM "users" WHERE "username" ILIKE ?) OR "claims"."reporter_id" IN (SELECT "id" FROM "users" WHERE "username" ILIKE ?)`, search, search)
+		}
+		if filter.Status != "" {
+			db = db.Where(`"claims"."status" = ?`, filter.Status)
+		} else {
+			// cancelled claims only show up when asked for explicitly
+			db = db.Where(`"claims"."status" <> ?`, entity.StatusCancelled)
+		}
+		if filter.Event != "" {
+			db = db.Where(`"claims"."event" = ?`, filter.Event)
+		}
+		if filter.PlayerID != "" {
+			db = db.Where(`"claims"."claimed_player_id" = ?`, filter.PlayerID)
+		}
+		if filter.From != "" {
+			db = db.Where(`"claims"."match_date" >= ?`, filter.From)
+		}
+		if filter.To != "" {
+			db = db.Where(`"claims"."match_date" <= ?`, filter.To)
+		}
+
+		return db
+	}
 }
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IClaimRevisionRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, revision *entity.ClaimRevision) error
+		CreateMany(ctx context.Context, tx *gorm.DB, revisions []*entity.ClaimRevision) error
+		GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.ClaimRevision, error)
+	}
+
+	claimRevisionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewClaimRevisionRepository(db *gorm.DB) *claimRevisionRepository {
+	return &claimRevisionRepository{
+		db: db,
+	}
+}
+
+func (crr *claimRevisionRepository) Create(ctx context.Context, tx *gorm.DB, revision *entity.ClaimRevision) error {
+	if tx == nil {
+		tx = crr.db
+	}
+
+	return tx.WithContext(ctx).Create(&revision).Error
+}
+
+func (crr *claimRevisionRepository) CreateMany(ctx context.Context, tx *gorm.DB, revisions []*entity.ClaimRevision) error {
+	if tx == nil {
+		tx = crr.db
+	}
+
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).CreateInBatches(revisions, 100).Error
+}
+
+func (crr *claimRevisionRepository) GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.ClaimRevision, error) {
+	if tx == nil {
+		tx = crr.db
+	}
+
+	var (
+		revisions []*entity.ClaimRevision
+		err       error
+	)
+
+	query := tx.WithContext(ctx).
+		Preload("Actor").
+		Where("subject_claim_id = ?", claimID).
+		Model(&entity.ClaimRevision{})
+	if err := query.Order(`"created_at" ASC`).Find(&revisions).Error; err != nil {
+		return []*entity.ClaimRevision{}, err
+	}
+
+	return revisions, err
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IDisputeRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, dispute *entity.Dispute) error
+		GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.Dispute, error)
+		GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Dispute, bool, error)
+		Update(ctx context.Context, tx *gorm.DB, dispute *entity.Dispute) error
+	}
+
+	disputeRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewDisputeRepository(db *gorm.DB) *disputeRepository {
+	return &disputeRepository{
+		db: db,
+	}
+}
+
+func (dr *disputeRepository) Create(ctx context.Context, tx *gorm.DB, dispute *entity.Dispute) error {
+	if tx == nil {
+		tx = dr.db
+	}
+
+	return tx.WithContext(ctx).Create(&dispute).Error
+}
+
+func (dr *disputeRepository) GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.Dispute, error) {
+	if tx == nil {
+		tx = dr.db
+	}
+
+	var (
+		disputes []*entity.Dispute
+		err      error
+	)
+
+	query := tx.WithContext(ctx).
+		Preload("Appellant").
+		Where("claim_id = ?", claimID).
+		Model(&entity.Dispute{})
+	if err := query.Order(`"created_at" DESC`).Find(&disputes).Error; err != nil {
+		return []*entity.Dispute{}, err
+	}
+
+	return disputes, err
+}
+
+func (dr *disputeRepository) GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Dispute, bool, error) {
+	if tx == nil {
+		tx = dr.db
+	}
+
+	var dispute *entity.Dispute
+	err := tx.WithContext(ctx).
+		Preload("Appellant").
+		Where("id = ?", &id).Take(&dispute).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Dispute{}, false, nil
+	}
+	if err != nil {
+		return &entity.Dispute{}, false, err
+	}
+
+	return dispute, true, nil
+}
+
+func (dr *disputeRepository) Update(ctx context.Context, tx *gorm.DB, dispute *entity.Dispute) error {
+	if tx == nil {
+		tx = dr.db
+	}
+
+	return tx.WithContext(ctx).
+		Model(&entity.Dispute{}).
+		Where("id = ?", dispute.ID).
+		Select("*").
+		Omit("id", "created_at", clause.Associations).
+		Updates(&dispute).Error
+}
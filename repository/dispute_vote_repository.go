@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IDisputeVoteRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, vote *entity.DisputeVote) error
+		GetByDisputeIDAndVoterID(ctx context.Context, tx *gorm.DB, disputeID, voterID *uuid.UUID) (*entity.DisputeVote, bool, error)
+	}
+
+	disputeVoteRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewDisputeVoteRepository(db *gorm.DB) *disputeVoteRepository {
+	return &disputeVoteRepository{
+		db: db,
+	}
+}
+
+func (dvr *disputeVoteRepository) Create(ctx context.Context, tx *gorm.DB, vote *entity.DisputeVote) error {
+	if tx == nil {
+		tx = dvr.db
+	}
+
+	return tx.WithContext(ctx).Create(&vote).Error
+}
+
+func (dvr *disputeVoteRepository) GetByDisputeIDAndVoterID(ctx context.Context, tx *gorm.DB, disputeID, voterID *uuid.UUID) (*entity.DisputeVote, bool, error) {
+	if tx == nil {
+		tx = dvr.db
+	}
+
+	var vote *entity.DisputeVote
+	err := tx.WithContext(ctx).
+		Where("dispute_id = ? AND voter_id = ?", &disputeID, &voterID).
+		Take(&vote).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.DisputeVote{}, false, nil
+	}
+	if err != nil {
+		return &entity.DisputeVote{}, false, err
+	}
+
+	return vote, true, nil
+}
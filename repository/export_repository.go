@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IExportRepository interface {
+		StreamClaims(ctx context.Context, tx *gorm.DB, filter dto.ClaimFilterRequest, fn func(row *dto.ClaimExportRow) error) error
+		StreamPlayerStats(ctx context.Context, tx *gorm.DB, fn func(row *dto.PlayerStatExportRow) error) error
+	}
+
+	exportRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewExportRepository(db *gorm.DB) *exportRepository {
+	return &exportRepository{
+		db: db,
+	}
+}
+
+// StreamClaims walks the filtered claims row by row through a database cursor,
+// so memory use stays flat no matter how long the history is.
+func (er *exportRepository) StreamClaims(ctx context.Context, tx *gorm.DB, filter dto.ClaimFilterRequest, fn func(row *dto.ClaimExportRow) error) error {
+	if tx == nil {
+		tx = er.db
+	}
+
+	voters := func(voteType entity.VoteType) string {
+		return `(SELECT COALESCE(STRING_AGG("u"."username", ',' ORDER BY "u"."username"), '') FROM "votes" "v" JOIN "users" "u" ON "u"."id" = "v"."voter_id" WHERE "v"."claim_id" = "claims"."id" AND "v"."deleted_at" IS NULL AND "v"."type" = '` + string(voteType) + `')`
+	}
+
+	rows, err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select(`"claims"."id", TO_CHAR("claims"."match_date", 'YYYY-MM-DD') AS "match_date", "claims"."event", "claims"."status", "claims"."total_player",
+			"claimed_player"."username" AS "claimed_player", COALESCE("reporter"."username", '') AS "reporter",
+			"claims"."approve_count", "claims"."reject_count",
+			` + voters(entity.VoteApprove) + ` AS "approved_by",
+			` + voters(entity.VoteReject) + ` AS "rejected_by",
+			"claims"."is_imported", "claims"."created_at"`).
+		Joins(`JOIN "users" "claimed_player" ON "claimed_player"."id" = "claims"."claimed_player_id"`).
+		Joins(`LEFT JOIN "users" "reporter" ON "reporter"."id" = "claims"."reporter_id"`).
+		Scopes(claimFilterScope(filter)).
+		Order(`"claims"."match_date" ASC, "claims"."created_at" ASC`).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.ClaimExportRow
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (er *exportRepository) StreamPlayerStats(ctx context.Context, tx *gorm.DB, fn func(row *dto.PlayerStatExportRow) error) error {
+	if tx == nil {
+		tx = er.db
+	}
+
+	rows, err := tx.WithContext(ctx).
+		Model(&entity.PlayerStat{}).
+		Select(`"player_stats"."player_id", "users"."username", "player_stats"."total_match", "player_stats"."king_count", "player_stats"."kong_count", "player_stats"."ngok_count",
+			"player_stats"."score", "player_stats"."scoring_version", "player_stats"."win_rate", "player_stats"."rating",
+			"player_stats"."current_king_streak", "player_stats"."longest_king_streak", "player_stats"."current_kong_streak", "player_stats"."longest_kong_streak",
+			"player_stats"."current_ngok_streak", "player_stats"."longest_ngok_streak"`).
+		Joins(`JOIN "users" ON "users"."id" = "player_stats"."player_id" AND "users"."deleted_at" IS NULL`).
+		Order(`"player_stats"."score" DESC, "users"."username" ASC`).
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row dto.PlayerStatExportRow
+		if err := tx.ScanRows(rows, &row); err != nil {
+			return err
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	INotificationRepository interface {
+		CreateMany(ctx context.Context, tx *gorm.DB, notifications []*entity.Notification) error
+		GetAllByUserID(ctx context.Context, tx *gorm.DB, userID *uuid.UUID) ([]*entity.Notification, error)
+		GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Notification, bool, error)
+		Update(ctx context.Context, tx *gorm.DB, notification *entity.Notification) error
+	}
+
+	notificationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewNotificationRepository(db *gorm.DB) *notificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (nr *notificationRepository) CreateMany(ctx context.Context, tx *gorm.DB, notifications []*entity.Notification) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(&notifications).Error
+}
+
+func (nr *notificationRepository) GetAllByUserID(ctx context.Context, tx *gorm.DB, userID *uuid.UUID) ([]*entity.Notification, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var (
+		notifications []*entity.Notification
+		err           error
+	)
+
+	query := tx.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Model(&entity.Notification{})
+	if err := query.Order(`"created_at" DESC`).Find(&notifications).Error; err != nil {
+		return []*entity.Notification{}, err
+	}
+
+	return notifications, err
+}
+
+func (nr *notificationRepository) GetDetailByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) (*entity.Notification, bool, error) {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	var notification *entity.Notification
+	err := tx.WithContext(ctx).Where("id = ?", &id).Take(&notification).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Notification{}, false, nil
+	}
+	if err != nil {
+		return &entity.Notification{}, false, err
+	}
+
+	return notification, true, nil
+}
+
+func (nr *notificationRepository) Update(ctx context.Context, tx *gorm.DB, notification *entity.Notification) error {
+	if tx == nil {
+		tx = nr.db
+	}
+
+	return tx.WithContext(ctx).Model(&entity.Notification{}).Where("id = ?", notification.ID).Updates(&notification).Error
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IPlayerStatRepository interface {
+		GetByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) (*entity.PlayerStat, bool, error)
+		RecomputeByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID, rule *entity.ScoringRule) (*entity.PlayerStat, error)
+		ApplyFinalizedClaim(ctx context.Context, tx *gorm.DB, claim *entity.Claim, rule *entity.ScoringRule) (*entity.PlayerStat, error)
+		GetLeaderboard(ctx context.Context, tx *gorm.DB, orderBy string, limit int) ([]*entity.PlayerStat, error)
+		RecomputeAll(ctx context.Context, tx *gorm.DB, rule *entity.ScoringRule) ([]*entity.PlayerStat, []*entity.PlayerStat, error)
+	}
+
+	playerStatRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPlayerStatRepository(db *gorm.DB) *playerStatRepository {
+	return &playerStatRepository{
+		db: db,
+	}
+}
+
+func (psr *playerStatRepository) GetByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) (*entity.PlayerStat, bool, error) {
+	if tx == nil {
+		tx = psr.db
+	}
+
+	var stat *entity.PlayerStat
+	err := tx.WithContext(ctx).Where("player_id = ?", &playerID).Take(&stat).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.PlayerStat{}, false, nil
+	}
+	if err != nil {
+		return &entity.PlayerStat{}, false, err
+	}
+
+	return stat, true, nil
+}
+
+// GetLeaderboard ranks players who have at least one approved claim by the
+// given column, breaking ties by score. orderBy must come from a whitelist.
+func (psr *playerStatRepository) GetLeaderboard(ctx context.Context, tx *gorm.DB, orderBy string, limit int) ([]*entity.PlayerStat, error) {
+	if tx == nil {
+		tx = psr.db
+	}
+
+	var stats []*entity.PlayerStat
+	err := tx.WithContext(ctx).
+		Preload("Player").
+		Where("total_match > 0").
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: orderBy}, Desc: true},
+			{Column: clause.Column{Name: "score"}, Desc: true},
+			{Column: clause.Column{Name: "total_match"}, Desc: true},
+		}}).
+		Limit(limit).
+		Find(&stats).Error
+	if err != nil {
+		return []*entity.PlayerStat{}, err
+	}
+
+	return stats, nil
+}
+
+// RecomputeByPlayerID rebuilds the player's counters, score and streaks from
+// their approved, non-deleted claims and upserts the result. Use it whenever a
+// claim stops counting or lands before the player's latest result.
+func (psr *playerStatRepository) RecomputeByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID, rule *entity.ScoringRule) (*entity.PlayerStat, error) {
+	if tx == nil {
+		tx = psr.db
+	}
+
+	stat, err := psr.countByPlayerID(ctx, tx, playerID, rule)
+	if err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	if err := psr.rescanStreaks(ctx, tx, stat); err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	if err := psr.save(ctx, tx, stat); err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	return stat, nil
+}
+
+// ApplyFinalizedClaim refreshes the claimed player's stats after the claim was
+// approved. When it is the player's latest result the stored streaks are
+// extended by its event instead of replaying the whole history; a claim for an
+// earlier match, or a player without a stats row yet, falls back to a rescan.
+func (psr *playerStatRepository) ApplyFinalizedClaim(ctx context.Context, tx *gorm.DB, claim *entity.Claim, rule *entity.ScoringRule) (*entity.PlayerStat, error) {
+	if tx == nil {
+		tx = psr.db
+	}
+
+	stat, err := psr.countByPlayerID(ctx, tx, &claim.ClaimedPlayerID, rule)
+	if err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	previous, found, err := psr.GetByPlayerID(ctx, tx, &claim.ClaimedPlayerID)
+	if err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	var later int64
+	err = tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Where("claimed_player_id = ? AND status = ? AND id <> ?", claim.ClaimedPlayerID, entity.StatusFinalApproved, claim.ID).
+		Where("(match_date, created_at) > (?, ?)", claim.MatchDate, claim.CreatedAt).
+		Count(&later).Error
+	if err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	if found && later == 0 {
+		copyStreaks(stat, previous)
+		extendStreak(stat, claim.Event)
+	} else if err := psr.rescanStreaks(ctx, tx, stat); err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	if err := psr.save(ctx, tx, stat); err != nil {
+		return &entity.PlayerStat{}, err
+	}
+
+	return stat, nil
+}
+
+// countByPlayerID totals the player's approved, non-deleted claims. When the
+// rule scales by player count, each claim's points are weighted by
+// TotalPlayer relative to the rule's baseline.
+func (psr *playerStatRepository) countByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID, rule *entity.ScoringRule) (*entity.PlayerStat, error) {
+	stat := &entity.PlayerStat{
+		PlayerID:       *playerID,
+		ScoringVersion: rule.Version,
+	}
+	err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select(`COUNT(*) AS total_match,
+			COUNT(*) FILTER (WHERE event = ?) AS king_count,
+			COUNT(*) FILTER (WHERE event = ?) AS kong_count,
+			COUNT(*) FILTER (WHERE event = ?) AS ngok_count,
+			COALESCE(ROUND(SUM(
+				CASE event WHEN ? THEN ? WHEN ? THEN ? WHEN ? THEN ? ELSE 0 END *
+				CASE WHEN ? THEN total_player::numeric / ? ELSE 1 END
+			)), 0)::int AS score`,
+			entity.EventKing, entity.EventKong, entity.EventNgok,
+			entity.EventKing, rule.KingPoints, entity.EventKong, rule.KongPoints, entity.EventNgok, rule.NgokPoints,
+			rule.ScaleByTotalPlayer, rule.BaselinePlayers).
+		Where("claimed_player_id = ? AND status = ?", playerID, entity.StatusFinalApproved).
+		Scan(stat).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if stat.TotalMatch > 0 {
+		stat.WinRate = float64(stat.KingCount) / float64(stat.TotalMatch)
+	}
+
+	return stat, nil
+}
+
+// rescanStreaks replays the player's approved results in match order.
+func (psr *playerStatRepository) rescanStreaks(ctx context.Context, tx *gorm.DB, stat *entity.PlayerStat) error {
+	var events []entity.ClaimEvent
+	err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Where("claimed_player_id = ? AND status = ?", stat.PlayerID, entity.StatusFinalApproved).
+		Order(`"match_date" ASC, "created_at" ASC`).
+		Pluck("event", &events).Error
+	if err != nil {
+		return err
+	}
+
+	applyStreaks(stat, events)
+	return nil
+}
+
+func (psr *playerStatRepository) save(ctx context.Context, tx *gorm.DB, stat *entity.PlayerStat) error {
+	return tx.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "player_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"total_match", "king_count", "kong_count", "ngok_count", "score", "scoring_version", "win_rate",
+				"current_king_streak", "longest_king_streak", "current_kong_streak", "longest_kong_streak", "current_ngok_streak", "longest_ngok_streak",
+				"updated_at", "deleted_at",
+			}),
+		}).
+		Create(stat).Error
+}
+
+// applyStreaks rebuilds the streaks from the player's approved results in
+// match order.
+func applyStreaks(stat *entity.PlayerStat, events []entity.ClaimEvent) {
+	copyStreaks(stat, &entity.PlayerStat{})
+	for _, event := range events {
+		extendStreak(stat, event)
+	}
+}
+
+// extendStreak records one more result. A run of one event ends as soon as
+// any other event is recorded, so at most one of the current streaks is
+// non-zero.
+func extendStreak(stat *entity.PlayerStat, event entity.ClaimEvent) {
+	streaks := map[entity.ClaimEvent][2]*int{
+		entity.EventKing: {&stat.CurrentKingStreak, &stat.LongestKingStreak},
+		entity.EventKong: {&stat.CurrentKongStreak, &stat.LongestKongStreak},
+		entity.EventNgok: {&stat.CurrentNgokStreak, &stat.LongestNgokStreak},
+	}
+	for other, streak := range streaks {
+		if other != event {
+			*streak[0] = 0
+		}
+	}
+
+	if streak, ok := streaks[event]; ok {
+		*streak[0]++
+		if *streak[0] > *streak[1] {
+			*streak[1] = *streak[0]
+		}
+	}
+}
+
+func copyStreaks(dst *entity.PlayerStat, src *entity.PlayerStat) {
+	dst.CurrentKingStreak, dst.LongestKingStreak = src.CurrentKingStreak, src.LongestKingStreak
+	dst.CurrentKongStreak, dst.LongestKongStreak = src.CurrentKongStreak, src.LongestKongStreak
+	dst.CurrentNgokStreak, dst.LongestNgokStreak = src.CurrentNgokStreak, src.LongestNgokStreak
+}
+
+// RecomputeAll rebuilds every player's stats under the given rule in a single
+// transaction and drops rows of players that no longer exist. It returns the
+// rows as they were before and after.
+func (psr *playerStatRepository) RecomputeAll(ctx context.Context, tx *gorm.DB, rule *entity.ScoringRule) ([]*entity.PlayerStat, []*entity.PlayerStat, error) {
+	if tx == nil {
+		tx = psr.db
+	}
+
+	var before, after []*entity.PlayerStat
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Player").Find(&before).Error; err != nil {
+			return err
+		}
+
+		var players []*entity.User
+		if err := tx.Order(`"username" ASC`).Find(&players).Error; err != nil {
+			return err
+		}
+
+		playerIDs := make([]uuid.UUID, 0, len(players))
+		for _, player := range players {
+			playerIDs = append(playerIDs, player.ID)
+		}
+
+		orphans := tx.Unscoped()
+		if len(playerIDs) > 0 {
+			orphans = orphans.Where("player_id NOT IN ?", playerIDs)
+		} else {
+			orphans = orphans.Where("1 = 1")
+		}
+		if err := orphans.Delete(&entity.PlayerStat{}).Error; err != nil {
+			return err
+		}
+
+		for _, player := range players {
+			stat, err := psr.RecomputeByPlayerID(ctx, tx, &player.ID, rule)
+			if err != nil {
+				return err
+			}
+
+			stat.Player = *player
+			after = append(after, stat)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return []*entity.PlayerStat{}, []*entity.PlayerStat{}, err
+	}
+
+	return before, after, nil
+}
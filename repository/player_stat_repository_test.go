@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+)
+
+// Extending the stored streaks one approval at a time must end where a full
+// rescan of the same history does.
+func TestExtendStreakMatchesRescan(t *testing.T) {
+	king, kong, ngok := entity.EventKing, entity.EventKong, entity.EventNgok
+	tests := []struct {
+		name   string
+		events []entity.ClaimEvent
+		want   entity.PlayerStat
+	}{
+		{
+			name:   "no results",
+			events: nil,
+			want:   entity.PlayerStat{},
+		},
+		{
+			name:   "single run",
+			events: []entity.ClaimEvent{king, king, king},
+			want:   entity.PlayerStat{CurrentKingStreak: 3, LongestKingStreak: 3},
+		},
+		{
+			name:   "longest run kept after it breaks",
+			events: []entity.ClaimEvent{king, king, king, kong, king},
+			want:   entity.PlayerStat{CurrentKingStreak: 1, LongestKingStreak: 3, LongestKongStreak: 1},
+		},
+		{
+			name:   "alternating events",
+			events: []entity.ClaimEvent{ngok, kong, ngok, ngok},
+			want:   entity.PlayerStat{LongestKongStreak: 1, CurrentNgokStreak: 2, LongestNgokStreak: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rescanned := &entity.PlayerStat{}
+			applyStreaks(rescanned, tt.events)
+
+			extended := &entity.PlayerStat{}
+			for _, event := range tt.events {
+				stored := &entity.PlayerStat{}
+				copyStreaks(stored, extended)
+				extendStreak(stored, event)
+				extended = stored
+			}
+
+			if !reflect.DeepEqual(*rescanned, tt.want) {
+				t.Errorf("applyStreaks = %+v, want %+v", *rescanned, tt.want)
+			}
+			if !reflect.DeepEqual(*extended, tt.want) {
+				t.Errorf("extendStreak = %+v, want %+v", *extended, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	IRatingHistoryRepository interface {
+		ReplaceAll(ctx context.Context, tx *gorm.DB, histories []*entity.RatingHistory, ratings map[uuid.UUID]float64) error
+		GetAllByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]*entity.RatingHistory, error)
+	}
+
+	ratingHistoryRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewRatingHistoryRepository(db *gorm.DB) *ratingHistoryRepository {
+	return &ratingHistoryRepository{
+		db: db,
+	}
+}
+
+// ReplaceAll swaps the whole rating history for a freshly replayed one and
+// writes each player's final rating onto their stats, in one transaction.
+func (rhr *ratingHistoryRepository) ReplaceAll(ctx context.Context, tx *gorm.DB, histories []*entity.RatingHistory, ratings map[uuid.UUID]float64) error {
+	if tx == nil {
+		tx = rhr.db
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("1 = 1").Delete(&entity.RatingHistory{}).Error; err != nil {
+			return err
+		}
+
+		if len(histories) > 0 {
+			if err := tx.CreateInBatches(&histories, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		// Players who dropped out of every match fall back to the default.
+		if err := tx.Model(&entity.PlayerStat{}).Where("1 = 1").Update("rating", entity.InitialRating).Error; err != nil {
+			return err
+		}
+
+		for playerID, rating := range ratings {
+			stat := &entity.PlayerStat{
+				PlayerID: playerID,
+				Rating:   rating,
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "player_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"rating", "updated_at"}),
+			}).Create(&stat).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (rhr *ratingHistoryRepository) GetAllByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]*entity.RatingHistory, error) {
+	if tx == nil {
+		tx = rhr.db
+	}
+
+	var histories []*entity.RatingHistory
+	err := tx.WithContext(ctx).
+		Where("player_id = ?", playerID).
+		Order(`"match_date" ASC`).
+		Find(&histories).Error
+	if err != nil {
+		return []*entity.RatingHistory{}, err
+	}
+
+	return histories, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IReactionRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, reaction *entity.Reaction) error
+		GetByClaimIDUserIDAndEmoji(ctx context.Context, tx *gorm.DB, claimID, userID *uuid.UUID, emoji string) (*entity.Reaction, bool, error)
+		DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error
+		CountByClaimIDs(ctx context.Context, tx *gorm.DB, claimIDs []uuid.UUID) (map[uuid.UUID][]dto.ReactionCountResponse, error)
+	}
+
+	reactionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewReactionRepository(db *gorm.DB) *reactionRepository {
+	return &reactionRepository{
+		db: db,
+	}
+}
+
+func (rr *reactionRepository) Create(ctx context.Context, tx *gorm.DB, reaction *entity.Reaction) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	return tx.WithContext(ctx).Create(&reaction).Error
+}
+
+func (rr *reactionRepository) GetByClaimIDUserIDAndEmoji(ctx context.Context, tx *gorm.DB, claimID, userID *uuid.UUID, emoji string) (*entity.Reaction, bool, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	var reaction *entity.Reaction
+	err := tx.WithContext(ctx).
+		Where("claim_id = ? AND user_id = ? AND emoji = ?", &claimID, &userID, emoji).
+		Take(&reaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.Reaction{}, false, nil
+	}
+	if err != nil {
+		return &entity.Reaction{}, false, err
+	}
+
+	return reaction, true, nil
+}
+
+func (rr *reactionRepository) DeleteByID(ctx context.Context, tx *gorm.DB, id *uuid.UUID) error {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	// reactions are removed for good so the same emoji can be added again
+	return tx.WithContext(ctx).Unscoped().Where("id = ?", id).Delete(&entity.Reaction{}).Error
+}
+
+func (rr *reactionRepository) CountByClaimIDs(ctx context.Context, tx *gorm.DB, claimIDs []uuid.UUID) (map[uuid.UUID][]dto.ReactionCountResponse, error) {
+	if tx == nil {
+		tx = rr.db
+	}
+
+	counts := make(map[uuid.UUID][]dto.ReactionCountResponse, len(claimIDs))
+	if len(claimIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ClaimID uuid.UUID
+		Emoji   string
+		Count   int
+	}
+	err := tx.WithContext(ctx).
+		Model(&entity.Reaction{}).
+		Select("claim_id, emoji, COUNT(*) AS count").
+		Where("claim_id IN ?", claimIDs).
+		Group("claim_id, emoji").
+		Order("count DESC, emoji ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return counts, err
+	}
+
+	for _, row := range rows {
+		counts[row.ClaimID] = append(counts[row.ClaimID], dto.ReactionCountResponse{
+			Emoji: row.Emoji,
+			Count: row.Count,
+		})
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IScoringRuleRepository interface {
+		Create(ctx context.Context, tx *gorm.DB, rule *entity.ScoringRule) error
+		GetLatest(ctx context.Context, tx *gorm.DB) (*entity.ScoringRule, bool, error)
+		GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.ScoringRule, error)
+	}
+
+	scoringRuleRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewScoringRuleRepository(db *gorm.DB) *scoringRuleRepository {
+	return &scoringRuleRepository{
+		db: db,
+	}
+}
+
+func (srr *scoringRuleRepository) Create(ctx context.Context, tx *gorm.DB, rule *entity.ScoringRule) error {
+	if tx == nil {
+		tx = srr.db
+	}
+
+	return tx.WithContext(ctx).Create(&rule).Error
+}
+
+func (srr *scoringRuleRepository) GetLatest(ctx context.Context, tx *gorm.DB) (*entity.ScoringRule, bool, error) {
+	if tx == nil {
+		tx = srr.db
+	}
+
+	var rule *entity.ScoringRule
+	err := tx.WithContext(ctx).Preload("CreatedBy").Order(`"version" DESC`).Take(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entity.ScoringRule{}, false, nil
+	}
+	if err != nil {
+		return &entity.ScoringRule{}, false, err
+	}
+
+	return rule, true, nil
+}
+
+func (srr *scoringRuleRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*entity.ScoringRule, error) {
+	if tx == nil {
+		tx = srr.db
+	}
+
+	var rules []*entity.ScoringRule
+	if err := tx.WithContext(ctx).Preload("CreatedBy").Order(`"version" DESC`).Find(&rules).Error; err != nil {
+		return []*entity.ScoringRule{}, err
+	}
+
+	return rules, nil
+}
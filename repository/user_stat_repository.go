@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	IUserStatRepository interface {
+		GetMonthlyByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]dto.MonthlyStatResponse, error)
+		GetWeekdayByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]dto.WeekdayStatResponse, error)
+		GetReportedByReporterID(ctx context.Context, tx *gorm.DB, reporterID *uuid.UUID) (dto.ReportedClaimStatResponse, error)
+		GetVotesByVoterID(ctx context.Context, tx *gorm.DB, voterID *uuid.UUID) (dto.VoteStatResponse, error)
+	}
+
+	userStatRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserStatRepository(db *gorm.DB) *userStatRepository {
+	return &userStatRepository{
+		db: db,
+	}
+}
+
+func (usr *userStatRepository) GetMonthlyByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]dto.MonthlyStatResponse, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var months []dto.MonthlyStatResponse
+	err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select(`to_char(match_date, 'YYYY-MM') AS month,
+			COUNT(*) AS total_match,
+			COUNT(*) FILTER (WHERE event = ?) AS king_count,
+			COUNT(*) FILTER (WHERE event = ?) AS kong_count,
+			COUNT(*) FILTER (WHERE event = ?) AS ngok_count,
+			COUNT(*) FILTER (WHERE event = ?)::float / COUNT(*) AS win_rate`,
+			entity.EventKing, entity.EventKong, entity.EventNgok, entity.EventKing).
+		Where("claimed_player_id = ? AND status = ?", playerID, entity.StatusFinalApproved).
+		Group("month").
+		Order("month ASC").
+		Scan(&months).Error
+	if err != nil {
+		return []dto.MonthlyStatResponse{}, err
+	}
+
+	return months, nil
+}
+
+// GetWeekdayByPlayerID groups by ISO day of week, so 1 is Monday.
+func (usr *userStatRepository) GetWeekdayByPlayerID(ctx context.Context, tx *gorm.DB, playerID *uuid.UUID) ([]dto.WeekdayStatResponse, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var days []dto.WeekdayStatResponse
+	err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select(`EXTRACT(ISODOW FROM match_date)::int AS day_of_week,
+			COUNT(*) AS total_match,
+			COUNT(*) FILTER (WHERE event = ?) AS king_count,
+			COUNT(*) FILTER (WHERE event = ?) AS ngok_count,
+			COUNT(*) FILTER (WHERE event = ?)::float / COUNT(*) AS win_rate`,
+			entity.EventKing, entity.EventNgok, entity.EventKing).
+		Where("claimed_player_id = ? AND status = ?", playerID, entity.StatusFinalApproved).
+		Group("day_of_week").
+		Order("day_of_week ASC").
+		Scan(&days).Error
+	if err != nil {
+		return []dto.WeekdayStatResponse{}, err
+	}
+
+	return days, nil
+}
+
+// GetReportedByReporterID summarizes the outcomes of claims the user filed.
+// The approval rate only considers claims that have been decided.
+func (usr *userStatRepository) GetReportedByReporterID(ctx context.Context, tx *gorm.DB, reporterID *uuid.UUID) (dto.ReportedClaimStatResponse, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var reported dto.ReportedClaimStatResponse
+	err := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = ?) AS approved,
+			COUNT(*) FILTER (WHERE status = ?) AS rejected,
+			COUNT(*) FILTER (WHERE status = ?) AS pending,
+			COALESCE(COUNT(*) FILTER (WHERE status = ?)::float / NULLIF(COUNT(*) FILTER (WHERE status IN (?, ?)), 0), 0) AS approval_rate`,
+			entity.StatusFinalApproved, entity.StatusFinalRejected, entity.StatusPending,
+			entity.StatusFinalApproved, entity.StatusFinalApproved, entity.StatusFinalRejected).
+		Where("reporter_id = ? AND status <> ?", reporterID, entity.StatusCancelled).
+		Scan(&reported).Error
+	if err != nil {
+		return dto.ReportedClaimStatResponse{}, err
+	}
+
+	return reported, nil
+}
+
+// GetVotesByVoterID counts the user's votes on live claims and how many of
+// the decided ones matched the final outcome.
+func (usr *userStatRepository) GetVotesByVoterID(ctx context.Context, tx *gorm.DB, voterID *uuid.UUID) (dto.VoteStatResponse, error) {
+	if tx == nil {
+		tx = usr.db
+	}
+
+	var votes dto.VoteStatResponse
+	err := tx.WithContext(ctx).
+		Model(&entity.Vote{}).
+		Joins(`JOIN "claims" ON "claims"."id" = "votes"."claim_id" AND "claims"."deleted_at" IS NULL AND "claims"."status" <> ?`, entity.StatusCancelled).
+		Select(`COUNT(*) AS votes_cast,
+			COUNT(*) FILTER (WHERE claims.status IN (?, ?)) AS decided,
+			COUNT(*) FILTER (WHERE (votes.type = ? AND claims.status = ?) OR (votes.type = ? AND claims.status = ?)) AS agreed`,
+			entity.StatusFinalApproved, entity.StatusFinalRejected,
+			entity.VoteApprove, entity.StatusFinalApproved, entity.VoteReject, entity.StatusFinalRejected).
+		Where("votes.voter_id = ?", voterID).
+		Scan(&votes).Error
+	if err != nil {
+		return dto.VoteStatResponse{}, err
+	}
+
+	if votes.Decided > 0 {
+		votes.AgreementRate = float64(votes.Agreed) / float64(votes.Decided)
+	}
+
+	return votes, nil
+}
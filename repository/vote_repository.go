@@ -14,6 +14,8 @@ type (
 		Create(ctx context.Context, tx *gorm.DB, vote *entity.Vote) error
 		GetByClaimIDAndVoterID(ctx context.Context, tx *gorm.DB, claimID, voterID *uuid.UUID) (*entity.Vote, bool, error)
 		GetAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) ([]*entity.Vote, error)
+		DeleteAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) error
+		CountByVoterID(ctx context.Context, tx *gorm.DB, voterID *uuid.UUID) (int64, error)
 	}
 
 	voteRepository struct {
@@ -76,3 +78,25 @@ func (vr *voteRepository) GetAllByClaimID(ctx context.Context, tx *gorm.DB, clai
 
 	return votes, err
 }
+
+func (vr *voteRepository) DeleteAllByClaimID(ctx context.Context, tx *gorm.DB, claimID *uuid.UUID) error {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	// votes are removed for good so voters can cast a fresh vote on the same claim
+	return tx.WithContext(ctx).Unscoped().Where("claim_id = ?", claimID).Delete(&entity.Vote{}).Error
+}
+
+func (vr *voteRepository) CountByVoterID(ctx context.Context, tx *gorm.DB, voterID *uuid.UUID) (int64, error) {
+	if tx == nil {
+		tx = vr.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Vote{}).Where("voter_id = ?", voterID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	IVoterStatRepository interface {
+		GetAll(ctx context.Context, tx *gorm.DB) ([]*dto.VoterStatRepositoryResponse, error)
+	}
+
+	voterStatRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewVoterStatRepository(db *gorm.DB) *voterStatRepository {
+	return &voterStatRepository{
+		db: db,
+	}
+}
+
+// GetAll aggregates every user's voting record, including users who never
+// voted and deactivated ones, matching the electorate userRepo.Count sees. Only votes on live, uncancelled claims count, and a user is
+// only expected to vote on claims filed since they joined.
+func (vsr *voterStatRepository) GetAll(ctx context.Context, tx *gorm.DB) ([]*dto.VoterStatRepositoryResponse, error) {
+	if tx == nil {
+		tx = vsr.db
+	}
+
+	eligibleClaims := tx.WithContext(ctx).
+		Model(&entity.Claim{}).
+		Select("COUNT(*)").
+		Where(`"claims"."created_at" >= "users"."created_at" AND "claims"."status" <> ?`, entity.StatusCancelled)
+
+	var stats []*dto.VoterStatRepositoryResponse
+	err := tx.WithContext(ctx).
+		Model(&entity.User{}).
+		Select(`"users"."id" AS voter_id, "users"."username", "users"."avatar_url",
+			COUNT(v.id) AS votes_cast,
+			COUNT(v.id) FILTER (WHERE c.status IN (?, ?)) AS decided,
+			COUNT(v.id) FILTER (WHERE (v.type = ? AND c.status = ?) OR (v.type = ? AND c.status = ?)) AS agreed,
+			COUNT(v.id) FILTER (WHERE v.type = ?) AS reject_count,
+			COALESCE(AVG(EXTRACT(EPOCH FROM v.created_at - c.created_at)), 0) AS avg_vote_seconds,
+			(?) AS eligible_claims`,
+			entity.StatusFinalApproved, entity.StatusFinalRejected,
+			entity.VoteApprove, entity.StatusFinalApproved, entity.VoteReject, entity.StatusFinalRejected,
+			entity.VoteReject,
+			eligibleClaims).
+		Joins(`LEFT JOIN ("votes" v JOIN "claims" c ON c.id = v.claim_id AND c.deleted_at IS NULL AND c.status <> ?) ON v.voter_id = "users"."id" AND v.deleted_at IS NULL`, entity.StatusCancelled).
+		Group(`"users"."id"`).
+		Order(`"users"."username" ASC`).
+		Scan(&stats).Error
+	if err != nil {
+		return []*dto.VoterStatRepositoryResponse{}, err
+	}
+
+	return stats, nil
+}
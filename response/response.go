@@ -8,6 +8,7 @@ type Response struct {
 	Timestamp time.Time `json:"timestamp,omitempty"`
 	Data      any       `json:"data,omitempty"`
 	Error     any       `json:"error,omitempty"`
+	Code      string    `json:"code,omitempty"`
 	Meta      any       `json:"meta,omitempty"`
 }
 
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func ClaimComment(route *gin.Engine, claimCommentHandler handler.IClaimCommentHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/claims/:id/comments").Use(middleware.Authentication(jwtService))
+	{
+		routes.POST("", claimCommentHandler.Create)
+		routes.GET("", claimCommentHandler.GetAllByClaimID)
+		routes.PATCH("/:comment_id", claimCommentHandler.Update)
+		routes.DELETE("/:comment_id", claimCommentHandler.DeleteByID)
+	}
+}
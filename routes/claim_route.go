@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/Amierza/mc-kalak-backend/constants"
 	"github.com/Amierza/mc-kalak-backend/handler"
 	"github.com/Amierza/mc-kalak-backend/jwt"
 	"github.com/Amierza/mc-kalak-backend/middleware"
@@ -19,5 +20,25 @@ func Claim(route *gin.Engine, claimHandler handler.IClaimHandler, jwtService jwt
 		// Vote
 		routes.POST("/:id/vote", claimHandler.Vote)
 		routes.GET("/:id/vote", claimHandler.GetAllVotesByClaimID)
+
+		// Cancel
+		routes.POST("/:id/cancel", claimHandler.Cancel)
+
+		// Dispute
+		routes.POST("/:id/disputes", claimHandler.OpenDispute)
+		routes.GET("/:id/disputes", claimHandler.GetAllDisputesByClaimID)
+		routes.POST("/:id/disputes/:dispute_id/vote", claimHandler.VoteDispute)
+
+		// History
+		routes.GET("/:id/history", claimHandler.GetHistoryByClaimID)
+
+		// Admin
+		routes.POST("/:id/reopen", middleware.Authorize(constants.ENUM_ROLE_ADMIN), claimHandler.Reopen)
+		routes.POST("/:id/resolve", middleware.Authorize(constants.ENUM_ROLE_ADMIN), claimHandler.Resolve)
+
+		// Trash
+		routes.GET("/trash", middleware.Authorize(constants.ENUM_ROLE_ADMIN), claimHandler.GetAllDeleted)
+		routes.POST("/trash/:id/restore", middleware.Authorize(constants.ENUM_ROLE_ADMIN), claimHandler.Restore)
+		routes.DELETE("/trash/:id", middleware.Authorize(constants.ENUM_ROLE_ADMIN), claimHandler.Purge)
 	}
 }
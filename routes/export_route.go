@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Export(route *gin.Engine, exportHandler handler.IExportHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/export").Use(middleware.Authentication(jwtService))
+	{
+		routes.GET("/claims", exportHandler.ExportClaims)
+		routes.GET("/stats", exportHandler.ExportStats)
+	}
+}
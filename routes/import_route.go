@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Import(route *gin.Engine, importHandler handler.IImportHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/import").Use(middleware.Authentication(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN))
+	{
+		routes.POST("/claims", importHandler.ImportClaims)
+	}
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Notification(route *gin.Engine, notificationHandler handler.INotificationHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/notifications").Use(middleware.Authentication(jwtService))
+	{
+		routes.GET("", notificationHandler.GetAll)
+		routes.PATCH("/:id/read", notificationHandler.MarkAsRead)
+	}
+}
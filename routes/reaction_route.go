@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Reaction(route *gin.Engine, reactionHandler handler.IReactionHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/claims/:id/reactions").Use(middleware.Authentication(jwtService))
+	{
+		routes.POST("", reactionHandler.Add)
+		routes.DELETE("/:emoji", reactionHandler.Remove)
+	}
+}
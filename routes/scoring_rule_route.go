@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func ScoringRule(route *gin.Engine, scoringRuleHandler handler.IScoringRuleHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/scoring-rules").Use(middleware.Authentication(jwtService))
+	{
+		routes.GET("", scoringRuleHandler.GetCurrent)
+		routes.GET("/history", scoringRuleHandler.GetAll)
+		routes.PUT("", middleware.Authorize(constants.ENUM_ROLE_ADMIN), scoringRuleHandler.Update)
+	}
+}
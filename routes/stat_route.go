@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/handler"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Stat(route *gin.Engine, statHandler handler.IStatHandler, jwtService jwt.IJWT) {
+	routes := route.Group("/api/v1/stats").Use(middleware.Authentication(jwtService))
+	{
+		routes.GET("/leaderboard", statHandler.GetLeaderboard)
+		routes.GET("/head-to-head", statHandler.GetHeadToHead)
+		routes.GET("/voters", statHandler.GetVoterStats)
+
+		// Admin
+		routes.POST("/recompute", middleware.Authorize(constants.ENUM_ROLE_ADMIN), statHandler.RecomputeAll)
+	}
+}
@@ -11,6 +11,9 @@ func User(route *gin.Engine, userHandler handler.IUserHandler, jwtService jwt.IJ
 	routes := route.Group("/api/v1/users").Use(middleware.Authentication(jwtService))
 	{
 		routes.GET("/profile", userHandler.GetProfile)
+		routes.GET("/profile/stats", userHandler.GetProfileStats)
 		routes.PATCH("/profile", userHandler.Update)
+		routes.GET("/:id/rating-history", userHandler.GetRatingHistory)
+		routes.GET("/:id/badges", userHandler.GetBadges)
 	}
 }
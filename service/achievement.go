@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// achievementProgress is everything a rule may look at for one user.
+type achievementProgress struct {
+	claims    []*entity.Claim // approved claims in match order
+	voteCount int64
+}
+
+type achievementRule struct {
+	achievement entity.Achievement
+	earned      func(progress *achievementProgress) bool
+}
+
+// achievementRules is the badge catalog. Adding a badge only takes a new
+// entry here; its row is synced into the achievements table on evaluation.
+var achievementRules = []achievementRule{
+	{
+		achievement: entity.Achievement{Code: "FIRST_KING", Name: "First Crown", Description: "Get your first approved KING", Icon: "👑"},
+		earned: func(progress *achievementProgress) bool {
+			return longestRun(progress.claims, entity.EventKing) >= 1
+		},
+	},
+	{
+		achievement: entity.Achievement{Code: "KING_STREAK_3", Name: "Dynasty", Description: "Get 3 approved KINGs in a row", Icon: "🔥"},
+		earned: func(progress *achievementProgress) bool {
+			return longestRun(progress.claims, entity.EventKing) >= 3
+		},
+	},
+	{
+		achievement: entity.Achievement{Code: "NGOK_WEEK_5", Name: "Rough Week", Description: "Get 5 approved NGOKs within a week", Icon: "🧂"},
+		earned: func(progress *achievementProgress) bool {
+			return maxInWindow(progress.claims, entity.EventNgok, 7*24*time.Hour) >= 5
+		},
+	},
+	{
+		achievement: entity.Achievement{Code: "VOTER_100", Name: "Civic Duty", Description: "Vote on 100 claims", Icon: "🗳️"},
+		earned: func(progress *achievementProgress) bool {
+			return progress.voteCount >= 100
+		},
+	},
+}
+
+// longestRun counts the most consecutive claims with the given event.
+func longestRun(claims []*entity.Claim, event entity.ClaimEvent) int {
+	longest, current := 0, 0
+	for _, claim := range claims {
+		if claim.Event != event {
+			current = 0
+			continue
+		}
+
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+
+	return longest
+}
+
+// maxInWindow counts the most claims with the given event whose match dates
+// fit within one window.
+func maxInWindow(claims []*entity.Claim, event entity.ClaimEvent, window time.Duration) int {
+	var dates []time.Time
+	for _, claim := range claims {
+		if claim.Event == event {
+			dates = append(dates, claim.MatchDate)
+		}
+	}
+
+	best, start := 0, 0
+	for end := range dates {
+		for dates[end].Sub(dates[start]) >= window {
+			start++
+		}
+		if end-start+1 > best {
+			best = end - start + 1
+		}
+	}
+
+	return best
+}
+
+// achievementEvaluator holds what award needs, so every service that
+// finalizes claims can evaluate badges the same way.
+type achievementEvaluator struct {
+	claimRepo        repository.IClaimRepository
+	voteRepo         repository.IVoteRepository
+	achievementRepo  repository.IAchievementRepository
+	notificationRepo repository.INotificationRepository
+	logger           *zap.Logger
+}
+
+// award evaluates the badge rules for each user and credits new badges to
+// claim, notifying whoever earned one. Awards are never revoked.
+func (ae *achievementEvaluator) award(ctx context.Context, tx *gorm.DB, claim *entity.Claim, userIDs []uuid.UUID) error {
+	catalog := make([]*entity.Achievement, 0, len(achievementRules))
+	for _, rule := range achievementRules {
+		achievement := rule.achievement
+		catalog = append(catalog, &achievement)
+	}
+	achievements, err := ae.achievementRepo.Sync(ctx, tx, catalog)
+	if err != nil {
+		return fmt.Errorf("Failed to sync achievements: %w", err)
+	}
+	achievementByCode := make(map[string]*entity.Achievement, len(achievements))
+	for _, achievement := range achievements {
+		achievementByCode[achievement.Code] = achievement
+	}
+
+	for _, userID := range userIDs {
+		claims, err := ae.claimRepo.GetAllFinalApprovedByPlayerID(ctx, tx, &userID)
+		if err != nil {
+			return fmt.Errorf("Failed to get approved claims by player id: %w", err)
+		}
+		voteCount, err := ae.voteRepo.CountByVoterID(ctx, tx, &userID)
+		if err != nil {
+			return fmt.Errorf("Failed to count votes by voter id: %w", err)
+		}
+		progress := &achievementProgress{
+			claims:    claims,
+			voteCount: voteCount,
+		}
+
+		for _, rule := range achievementRules {
+			achievement, ok := achievementByCode[rule.achievement.Code]
+			if !ok || !rule.earned(progress) {
+				continue
+			}
+
+			awarded, err := ae.achievementRepo.Award(ctx, tx, &entity.UserAchievement{
+				UserID:        userID,
+				AchievementID: achievement.ID,
+				ClaimID:       &claim.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("Failed to award achievement: %w", err)
+			}
+			if !awarded {
+				continue
+			}
+
+			logger.FromContext(ctx, ae.logger).Info("achievement awarded",
+				zap.String("claim_id", claim.ID.String()),
+				zap.String("awarded_to", userID.String()),
+				zap.String("achievement", achievement.Code),
+			)
+
+			notification := &entity.Notification{
+				Type:    entity.NotificationAchievement,
+				Message: fmt.Sprintf("You unlocked the %s %s badge: %s", achievement.Icon, achievement.Name, achievement.Description),
+				UserID:  userID,
+				ClaimID: &claim.ID,
+			}
+			if err := ae.notificationRepo.CreateMany(ctx, tx, []*entity.Notification{notification}); err != nil {
+				return fmt.Errorf("Failed to create notifications: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/Amierza/mc-kalak-backend/dto"
 	"github.com/Amierza/mc-kalak-backend/helper"
 	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/logger"
 	"github.com/Amierza/mc-kalak-backend/repository"
+	"go.uber.org/zap"
 )
 
 type (
@@ -18,38 +20,47 @@ type (
 	authService struct {
 		userRepo repository.IUserRepository
 		jwt      jwt.IJWT
+		logger   *zap.Logger
 	}
 )
 
-func NewAuthService(userRepo repository.IUserRepository, jwt jwt.IJWT) *authService {
+func NewAuthService(userRepo repository.IUserRepository, jwt jwt.IJWT, logger *zap.Logger) *authService {
 	return &authService{
 		userRepo: userRepo,
 		jwt:      jwt,
+		logger:   logger,
 	}
 }
 
+// Login answers an unknown username and a wrong password with the same
+// error, so the response can't be used to find out which usernames exist.
+// Which of the two it was is only logged.
 func (as *authService) Login(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error) {
 	user, found, err := as.userRepo.GetByUsername(ctx, nil, &req.Username)
 	if err != nil {
-		return dto.LoginResponse{}, fmt.Errorf("Failed to get user by username: %v\n", err)
+		return dto.LoginResponse{}, fmt.Errorf("Failed to get user by username: %w", err)
 	}
 	if !found {
-		return dto.LoginResponse{}, fmt.Errorf("User not found with username: %s\n", req.Username)
+		logger.FromContext(ctx, as.logger).Warn("login failed: unknown username", zap.String("username", req.Username))
+		return dto.LoginResponse{}, dto.ErrInvalidCredentials
 	}
 
 	checkPassword, err := helper.CheckPassword(user.Password, []byte(req.Password))
 	if err != nil {
-		return dto.LoginResponse{}, fmt.Errorf("Failed to check password: %v\n", err)
+		return dto.LoginResponse{}, fmt.Errorf("Failed to check password: %w", err)
 	}
 	if !checkPassword {
-		return dto.LoginResponse{}, fmt.Errorf("Incorrect password for username: %s\n", req.Username)
+		logger.FromContext(ctx, as.logger).Warn("login failed: incorrect password", zap.String("username", req.Username))
+		return dto.LoginResponse{}, dto.ErrInvalidCredentials
 	}
 
-	token, err := as.jwt.GenerateToken(user.ID.String())
+	token, err := as.jwt.GenerateToken(user.ID.String(), user.Role)
 	if err != nil {
-		return dto.LoginResponse{}, fmt.Errorf("Failed to generate token for userID %s: %v\n", user.ID.String(), err)
+		return dto.LoginResponse{}, fmt.Errorf("Failed to generate token for userID %s: %w", user.ID.String(), err)
 	}
 
+	logger.FromContext(ctx, as.logger).Info("user logged in", zap.String(logger.UserIDKey, user.ID.String()))
+
 	return dto.LoginResponse{
 		Token: token,
 	}, nil
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	IClaimCommentService interface {
+		Create(ctx context.Context, req *dto.CreateClaimCommentRequest) (*dto.ClaimCommentResponse, error)
+		GetAllByClaimID(ctx context.Context, claimID *uuid.UUID) ([]*dto.ClaimCommentResponse, error)
+		Update(ctx context.Context, req *dto.UpdateClaimCommentRequest) (*dto.ClaimCommentResponse, error)
+		DeleteByID(ctx context.Context, claimID, id *uuid.UUID) (*dto.ClaimCommentResponse, error)
+	}
+
+	claimCommentService struct {
+		db               *gorm.DB
+		claimCommentRepo repository.IClaimCommentRepository
+		claimRepo        repository.IClaimRepository
+		userRepo         repository.IUserRepository
+		notificationRepo repository.INotificationRepository
+		logger           *zap.Logger
+	}
+)
+
+func NewClaimCommentService(db *gorm.DB, claimCommentRepo repository.IClaimCommentRepository, claimRepo repository.IClaimRepository, userRepo repository.IUserRepository, notificationRepo repository.INotificationRepository, logger *zap.Logger) *claimCommentService {
+	return &claimCommentService{
+		db:               db,
+		claimCommentRepo: claimCommentRepo,
+		claimRepo:        claimRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		logger:           logger,
+	}
+}
+
+func (ccs *claimCommentService) Create(ctx context.Context, req *dto.CreateClaimCommentRequest) (*dto.ClaimCommentResponse, error) {
+	authorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, err
+	}
+
+	author, found, err := ccs.userRepo.GetDetailByID(ctx, nil, &authorID)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get author by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed author not found: %w", dto.ErrNotFound)
+	}
+
+	claim, found, err := ccs.claimRepo.GetDetailByID(ctx, nil, &req.ClaimID)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	recipients := []uuid.UUID{claim.ReporterID, claim.ClaimedPlayerID}
+	notificationType := entity.NotificationNewComment
+	if req.ParentID != nil {
+		parent, found, err := ccs.claimCommentRepo.GetDetailByID(ctx, nil, req.ParentID)
+		if err != nil {
+			return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get parent comment by id: %w", err)
+		}
+		if !found || parent.ClaimID != claim.ID {
+			return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed parent comment not found: %w", dto.ErrNotFound)
+		}
+
+		recipients = []uuid.UUID{parent.AuthorID}
+		notificationType = entity.NotificationCommentReply
+	}
+
+	comment := &entity.ClaimComment{
+		Content:  req.Content,
+		ClaimID:  claim.ID,
+		AuthorID: author.ID,
+		Author:   *author,
+		ParentID: req.ParentID,
+	}
+	userIDs := uniqueUserIDs(author.ID, recipients...)
+	err = ccs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ccs.claimCommentRepo.Create(ctx, tx, comment); err != nil {
+			return fmt.Errorf("Failed to create comment: %w", err)
+		}
+
+		notifications := make([]*entity.Notification, 0, len(userIDs))
+		for _, userID := range userIDs {
+			notifications = append(notifications, &entity.Notification{
+				Type:    notificationType,
+				Message: fmt.Sprintf("%s commented on the %s claim for %s: %s", author.Username, claim.Event, claim.ClaimedPlayer.Username, comment.Content),
+				UserID:  userID,
+				ClaimID: &claim.ID,
+			})
+		}
+		if err := ccs.notificationRepo.CreateMany(ctx, tx, notifications); err != nil {
+			return fmt.Errorf("Failed to create notifications: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, err
+	}
+
+	logger.FromContext(ctx, ccs.logger).Debug("comment created",
+		zap.String("claim_id", claim.ID.String()),
+		zap.String("comment_id", comment.ID.String()),
+		zap.Int("notified", len(userIDs)),
+	)
+
+	res := &dto.ClaimCommentResponse{
+		ID:       comment.ID,
+		ClaimID:  comment.ClaimID,
+		ParentID: comment.ParentID,
+		Content:  comment.Content,
+		Author: dto.UserSimpleResponse{
+			ID:        comment.Author.ID,
+			Username:  comment.Author.Username,
+			AvatarURL: comment.Author.AvatarURL,
+		},
+		Replies: []*dto.ClaimCommentResponse{},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+func (ccs *claimCommentService) GetAllByClaimID(ctx context.Context, claimID *uuid.UUID) ([]*dto.ClaimCommentResponse, error) {
+	_, found, err := ccs.claimRepo.GetDetailByID(ctx, nil, claimID)
+	if err != nil {
+		return []*dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return []*dto.ClaimCommentResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	datas, err := ccs.claimCommentRepo.GetAllByClaimID(ctx, nil, claimID)
+	if err != nil {
+		return []*dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get all comments by claim ID: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*dto.ClaimCommentResponse, len(datas))
+	for _, comment := range datas {
+		byID[comment.ID] = &dto.ClaimCommentResponse{
+			ID:       comment.ID,
+			ClaimID:  comment.ClaimID,
+			ParentID: comment.ParentID,
+			Content:  comment.Content,
+			Author: dto.UserSimpleResponse{
+				ID:        comment.Author.ID,
+				Username:  comment.Author.Username,
+				AvatarURL: comment.Author.AvatarURL,
+			},
+			Replies: []*dto.ClaimCommentResponse{},
+			TimestampTemplate: dto.TimestampTemplate{
+				CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+			},
+		}
+	}
+
+	// replies are deleted along with their parent, but older ones may still
+	// hang off a deleted parent and are left out like CountByClaimIDs does
+	comments := make([]*dto.ClaimCommentResponse, 0, len(datas))
+	for _, comment := range datas {
+		if comment.ParentID == nil {
+			comments = append(comments, byID[comment.ID])
+			continue
+		}
+
+		if parent, ok := byID[*comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, byID[comment.ID])
+		}
+	}
+
+	return comments, nil
+}
+
+func (ccs *claimCommentService) Update(ctx context.Context, req *dto.UpdateClaimCommentRequest) (*dto.ClaimCommentResponse, error) {
+	authorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, err
+	}
+
+	comment, found, err := ccs.claimCommentRepo.GetDetailByID(ctx, nil, &req.ID)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get comment by id: %w", err)
+	}
+	if !found || comment.ClaimID != req.ClaimID {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed comment not found: %w", dto.ErrNotFound)
+	}
+	if comment.AuthorID != authorID {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed only the author can edit this comment: %w", dto.ErrForbidden)
+	}
+
+	comment.Content = req.Content
+
+	if err := ccs.claimCommentRepo.Update(ctx, nil, comment); err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to update comment: %w", err)
+	}
+
+	res := &dto.ClaimCommentResponse{
+		ID:       comment.ID,
+		ClaimID:  comment.ClaimID,
+		ParentID: comment.ParentID,
+		Content:  comment.Content,
+		Author: dto.UserSimpleResponse{
+			ID:        comment.Author.ID,
+			Username:  comment.Author.Username,
+			AvatarURL: comment.Author.AvatarURL,
+		},
+		Replies: []*dto.ClaimCommentResponse{},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+func (ccs *claimCommentService) DeleteByID(ctx context.Context, claimID, id *uuid.UUID) (*dto.ClaimCommentResponse, error) {
+	authorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, err
+	}
+
+	deletedComment, found, err := ccs.claimCommentRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to get comment by id: %w", err)
+	}
+	if !found || deletedComment.ClaimID != *claimID {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed comment not found: %w", dto.ErrNotFound)
+	}
+	if deletedComment.AuthorID != authorID {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed only the author can delete this comment: %w", dto.ErrForbidden)
+	}
+
+	if err := ccs.claimCommentRepo.DeleteByID(ctx, nil, id); err != nil {
+		return &dto.ClaimCommentResponse{}, fmt.Errorf("Failed to delete comment by id: %w", err)
+	}
+
+	res := &dto.ClaimCommentResponse{
+		ID:       deletedComment.ID,
+		ClaimID:  deletedComment.ClaimID,
+		ParentID: deletedComment.ParentID,
+		Content:  deletedComment.Content,
+		Author: dto.UserSimpleResponse{
+			ID:        deletedComment.Author.ID,
+			Username:  deletedComment.Author.Username,
+			AvatarURL: deletedComment.Author.AvatarURL,
+		},
+		Replies: []*dto.ClaimCommentResponse{},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: deletedComment.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: deletedComment.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
@@ -2,66 +2,368 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Amierza/mc-kalak-backend/constants"
 	"github.com/Amierza/mc-kalak-backend/dto"
 	"github.com/Amierza/mc-kalak-backend/entity"
 	"github.com/Amierza/mc-kalak-backend/helper"
-	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/logger"
 	"github.com/Amierza/mc-kalak-backend/repository"
 	"github.com/Amierza/mc-kalak-backend/response"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 type (
 	IClaimService interface {
 		Create(ctx context.Context, req *dto.CreateClaimRequest) (*dto.ClaimResponse, error)
-		GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.ClaimPaginationResponse, error)
+		GetAllWithPagination(ctx context.Context, req response.PaginationRequest, filter dto.ClaimFilterRequest) (dto.ClaimPaginationResponse, error)
 		GetDetailByID(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
 		Update(ctx context.Context, req *dto.UpdateClaimRequest) (*dto.ClaimResponse, error)
 		DeleteByID(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
 		Vote(ctx context.Context, req *dto.ClaimVoteRequest) (*dto.ClaimResponse, error)
 		GetAllVotesByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.ClaimVoteResponse, error)
+		GetHistoryByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.ClaimRevisionResponse, error)
+		Reopen(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
+		Cancel(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
+		Resolve(ctx context.Context, req *dto.ResolveClaimRequest) (*dto.ClaimResponse, error)
+		GetAllDeletedWithPagination(ctx context.Context, req response.PaginationRequest) (dto.ClaimPaginationResponse, error)
+		Restore(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
+		Purge(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error)
+		OpenDispute(ctx context.Context, req *dto.CreateDisputeRequest) (*dto.DisputeResponse, error)
+		VoteDispute(ctx context.Context, req *dto.DisputeVoteRequest) (*dto.DisputeResponse, error)
+		GetAllDisputesByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.DisputeResponse, error)
 	}
 
 	claimService struct {
-		claimRepo repository.IClaimRepository
-		userRepo  repository.IUserRepository
-		voteRepo  repository.IVoteRepository
-		jwt       jwt.IJWT
+		db                *gorm.DB
+		claimRepo         repository.IClaimRepository
+		userRepo          repository.IUserRepository
+		voteRepo          repository.IVoteRepository
+		claimRevisionRepo repository.IClaimRevisionRepository
+		notificationRepo  repository.INotificationRepository
+		playerStatRepo    repository.IPlayerStatRepository
+		disputeRepo       repository.IDisputeRepository
+		disputeVoteRepo   repository.IDisputeVoteRepository
+		claimCommentRepo  repository.IClaimCommentRepository
+		reactionRepo      repository.IReactionRepository
+		scoringRuleRepo   repository.IScoringRuleRepository
+		ratingHistoryRepo repository.IRatingHistoryRepository
+		achievementRepo   repository.IAchievementRepository
+		voterStatRepo     repository.IVoterStatRepository
+		logger            *zap.Logger
 	}
 )
 
-func NewClaimService(claimRepo repository.IClaimRepository, userRepo repository.IUserRepository, voteRepo repository.IVoteRepository, jwt jwt.IJWT) *claimService {
+// An appeal only overturns a finalized claim with a two-thirds supermajority,
+// a higher bar than the simple majority that finalized it.
+const (
+	disputeUpholdNumerator   = 2
+	disputeUpholdDenominator = 3
+)
+
+func NewClaimService(db *gorm.DB, claimRepo repository.IClaimRepository, userRepo repository.IUserRepository, voteRepo repository.IVoteRepository, claimRevisionRepo repository.IClaimRevisionRepository, notificationRepo repository.INotificationRepository, playerStatRepo repository.IPlayerStatRepository, disputeRepo repository.IDisputeRepository, disputeVoteRepo repository.IDisputeVoteRepository, claimCommentRepo repository.IClaimCommentRepository, reactionRepo repository.IReactionRepository, scoringRuleRepo repository.IScoringRuleRepository, ratingHistoryRepo repository.IRatingHistoryRepository, achievementRepo repository.IAchievementRepository, voterStatRepo repository.IVoterStatRepository, logger *zap.Logger) *claimService {
 	return &claimService{
-		claimRepo: claimRepo,
-		userRepo:  userRepo,
-		voteRepo:  voteRepo,
-		jwt:       jwt,
+		db:                db,
+		claimRepo:         claimRepo,
+		userRepo:          userRepo,
+		voteRepo:          voteRepo,
+		claimRevisionRepo: claimRevisionRepo,
+		notificationRepo:  notificationRepo,
+		playerStatRepo:    playerStatRepo,
+		disputeRepo:       disputeRepo,
+		disputeVoteRepo:   disputeVoteRepo,
+		claimCommentRepo:  claimCommentRepo,
+		reactionRepo:      reactionRepo,
+		scoringRuleRepo:   scoringRuleRepo,
+		ratingHistoryRepo: ratingHistoryRepo,
+		achievementRepo:   achievementRepo,
+		voterStatRepo:     voterStatRepo,
+		logger:            logger,
+	}
+}
+
+// newClaimRevision builds the audit entry for one change to a claim.
+func newClaimRevision(claimID, actorID uuid.UUID, action entity.RevisionAction, changes []dto.ClaimFieldChange, reason string) (*entity.ClaimRevision, error) {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal claim changes: %w", err)
+	}
+
+	return &entity.ClaimRevision{
+		Action:         action,
+		Changes:        string(changesJSON),
+		Reason:         reason,
+		ClaimID:        &claimID,
+		SubjectClaimID: claimID,
+		ActorID:        actorID,
+	}, nil
+}
+
+func (cs *claimService) recordRevision(ctx context.Context, tx *gorm.DB, claimID, actorID uuid.UUID, action entity.RevisionAction, changes []dto.ClaimFieldChange, reason string) error {
+	revision, err := newClaimRevision(claimID, actorID, action, changes, reason)
+	if err != nil {
+		return err
+	}
+	if err := cs.claimRevisionRepo.Create(ctx, tx, revision); err != nil {
+		return fmt.Errorf("Failed to create claim revision: %w", err)
+	}
+
+	logger.FromContext(ctx, cs.logger).Info("claim changed",
+		zap.String("claim_id", claimID.String()),
+		zap.String("action", string(action)),
+		zap.Int("changed_fields", len(changes)),
+	)
+
+	return nil
+}
+
+// diffClaim lists the audited fields whose value differs between before and
+// after. A nil claim on either side counts as empty, so creations and
+// deletions record a full snapshot.
+func diffClaim(before, after *entity.Claim) []dto.ClaimFieldChange {
+	fields := []string{"event", "status", "match_date", "total_player", "screenshot_url", "claimed_player_id", "reporter_id"}
+	oldValues := claimFieldValues(before)
+	newValues := claimFieldValues(after)
+
+	changes := make([]dto.ClaimFieldChange, 0, len(fields))
+	for _, field := range fields {
+		if oldValues[field] == newValues[field] {
+			continue
+		}
+
+		changes = append(changes, dto.ClaimFieldChange{
+			Field: field,
+			Old:   oldValues[field],
+			New:   newValues[field],
+		})
+	}
+
+	return changes
+}
+
+// isSubstantiveEdit reports whether an edit changes what voters were asked to
+// judge. Swapping the screenshot alone keeps the existing votes.
+func isSubstantiveEdit(before, after *entity.Claim) bool {
+	return before.Event != after.Event ||
+		before.ClaimedPlayerID != after.ClaimedPlayerID ||
+		!before.MatchDate.Equal(after.MatchDate) ||
+		before.TotalPlayer != after.TotalPlayer
+}
+
+// resetVotes clears the tally so the claim is voted on from scratch and
+// returns the removed votes, whose voters still need to be told.
+func (cs *claimService) resetVotes(ctx context.Context, tx *gorm.DB, claim *entity.Claim) ([]entity.Vote, error) {
+	votes := claim.Votes
+	if len(votes) == 0 {
+		return nil, nil
+	}
+
+	if err := cs.voteRepo.DeleteAllByClaimID(ctx, tx, &claim.ID); err != nil {
+		return nil, fmt.Errorf("Failed to reset votes: %w", err)
+	}
+
+	claim.ApproveCount = 0
+	claim.RejectCount = 0
+	claim.Votes = nil
+
+	return votes, nil
+}
+
+func votesResetChange(votes []entity.Vote) dto.ClaimFieldChange {
+	return dto.ClaimFieldChange{
+		Field: "votes",
+		Old:   strconv.Itoa(len(votes)),
+		New:   "0",
+	}
+}
+
+func (cs *claimService) notifyVoteReset(ctx context.Context, tx *gorm.DB, claim *entity.Claim, votes []entity.Vote, actorID uuid.UUID, message string) error {
+	voterIDs := make([]uuid.UUID, 0, len(votes))
+	for _, vote := range votes {
+		voterIDs = append(voterIDs, vote.VoterID)
+	}
+
+	return cs.notify(ctx, tx, claim, entity.NotificationVoteReset, message, uniqueUserIDs(actorID, voterIDs...)...)
+}
+
+func (cs *claimService) notify(ctx context.Context, tx *gorm.DB, claim *entity.Claim, notificationType entity.NotificationType, message string, userIDs ...uuid.UUID) error {
+	notifications := make([]*entity.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, &entity.Notification{
+			Type:    notificationType,
+			Message: message,
+			UserID:  userID,
+			ClaimID: &claim.ID,
+		})
+	}
+
+	if err := cs.notificationRepo.CreateMany(ctx, tx, notifications); err != nil {
+		return fmt.Errorf("Failed to create notifications: %w", err)
+	}
+
+	return nil
+}
+
+// refreshStats recomputes the claimed player's stats when the claim's status
+// means it counts, or used to count, towards them.
+func (cs *claimService) refreshStats(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error {
+	if !isFinalStatus(claim.Status) {
+		return nil
+	}
+
+	rule, err := currentScoringRule(ctx, tx, cs.scoringRuleRepo)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cs.playerStatRepo.RecomputeByPlayerID(ctx, tx, &claim.ClaimedPlayerID, rule); err != nil {
+		return fmt.Errorf("Failed to recompute player stats: %w", err)
+	}
+
+	if _, err := recomputeRatings(ctx, tx, cs.claimRepo, cs.ratingHistoryRepo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// finalizeStats counts a newly approved claim towards the claimed player's
+// stats, extending their streaks rather than replaying their history.
+func (cs *claimService) finalizeStats(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error {
+	rule, err := currentScoringRule(ctx, tx, cs.scoringRuleRepo)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cs.playerStatRepo.ApplyFinalizedClaim(ctx, tx, claim, rule); err != nil {
+		return fmt.Errorf("Failed to update player stats: %w", err)
+	}
+
+	if _, err := recomputeRatings(ctx, tx, cs.claimRepo, cs.ratingHistoryRepo); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// awardAchievements evaluates the badge rules for everyone a finalized claim
+// touches: the claimed player and its voters. Awards are never revoked, so a
+// later reversal of the claim keeps badges already earned.
+func (cs *claimService) awardAchievements(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error {
+	if !isFinalStatus(claim.Status) {
+		return nil
+	}
+
+	votes, err := cs.voteRepo.GetAllByClaimID(ctx, tx, &claim.ID)
+	if err != nil {
+		return fmt.Errorf("Failed to get all votes by claim id: %w", err)
+	}
+	voterIDs := make([]uuid.UUID, 0, len(votes))
+	for _, vote := range votes {
+		voterIDs = append(voterIDs, vote.VoterID)
+	}
+
+	evaluator := &achievementEvaluator{
+		claimRepo:        cs.claimRepo,
+		voteRepo:         cs.voteRepo,
+		achievementRepo:  cs.achievementRepo,
+		notificationRepo: cs.notificationRepo,
+		logger:           cs.logger,
+	}
+	return evaluator.award(ctx, tx, claim, uniqueUserIDs(uuid.Nil, append([]uuid.UUID{claim.ClaimedPlayerID}, voterIDs...)...))
+}
+
+// resolveWeightedVotes is the reputation-weighted counterpart of the simple
+// majority: approval needs more than half of all users' combined weight, and
+// the claim is rejected once the approvals plus everyone yet to vote can no
+// longer reach it. With equal weights it decides exactly like the count.
+func (cs *claimService) resolveWeightedVotes(ctx context.Context, tx *gorm.DB, claim *entity.Claim) (entity.ClaimStatus, error) {
+	weights, err := voteWeights(ctx, tx, cs.voterStatRepo)
+	if err != nil {
+		return claim.Status, err
+	}
+
+	votes, err := cs.voteRepo.GetAllByClaimID(ctx, tx, &claim.ID)
+	if err != nil {
+		return claim.Status, fmt.Errorf("Failed to get all votes by claim id: %w", err)
+	}
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	approveWeight, castWeight := 0.0, 0.0
+	for _, vote := range votes {
+		weight, ok := weights[vote.VoterID]
+		if !ok {
+			continue
+		}
+
+		castWeight += weight
+		if vote.Type == entity.VoteApprove {
+			approveWeight += weight
+		}
+	}
+
+	switch {
+	case approveWeight > totalWeight/2:
+		return entity.StatusFinalApproved, nil
+	case approveWeight+(totalWeight-castWeight) <= totalWeight/2:
+		return entity.StatusFinalRejected, nil
+	default:
+		return claim.Status, nil
+	}
+}
+
+func claimFieldValues(claim *entity.Claim) map[string]string {
+	if claim == nil {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		"event":             string(claim.Event),
+		"status":            string(claim.Status),
+		"match_date":        claim.MatchDate.Format("2006-01-02 15:04:05"),
+		"total_player":      strconv.Itoa(claim.TotalPlayer),
+		"screenshot_url":    claim.ScreenshotURL,
+		"claimed_player_id": claim.ClaimedPlayerID.String(),
+		"reporter_id":       claim.ReporterID.String(),
 	}
 }
 
 func (cs *claimService) Create(ctx context.Context, req *dto.CreateClaimRequest) (*dto.ClaimResponse, error) {
-	reporter, found, err := cs.userRepo.GetDetailByID(ctx, nil, &req.ReporterID)
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	reporter, found, err := cs.userRepo.GetDetailByID(ctx, nil, &actorID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get reporter by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get reporter by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed reporter not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed reporter not found: %w", dto.ErrNotFound)
 	}
 
 	claimedPlayer, found, err := cs.userRepo.GetDetailByID(ctx, nil, &req.ClaimedPlayerID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claimed player by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claimed player by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claimed player not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claimed player not found: %w", dto.ErrNotFound)
 	}
 
 	date, err := helper.ParseDateTime(req.MatchDate)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed parse date: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed parse date: %w: %w", dto.ErrValidationFailed, err)
 	}
 
 	claim := &entity.Claim{
@@ -79,8 +381,15 @@ func (cs *claimService) Create(ctx context.Context, req *dto.CreateClaimRequest)
 		Reporter:        *reporter,
 	}
 
-	if err := cs.claimRepo.Create(ctx, nil, claim); err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to create claim: %v\n", err)
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.claimRepo.Create(ctx, tx, claim); err != nil {
+			return fmt.Errorf("Failed to create claim: %w", err)
+		}
+
+		return cs.recordRevision(ctx, tx, claim.ID, actorID, entity.RevisionCreate, diffClaim(nil, claim), "")
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
 	}
 
 	res := &dto.ClaimResponse{
@@ -92,6 +401,7 @@ func (cs *claimService) Create(ctx context.Context, req *dto.CreateClaimRequest)
 		ScreenshotURL: claim.ScreenshotURL,
 		ApproveCount:  claim.ApproveCount,
 		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
 		ClaimedPlayer: dto.UserSimpleResponse{
 			ID:        claim.ClaimedPlayer.ID,
 			Username:  claim.ClaimedPlayer.Username,
@@ -111,14 +421,32 @@ func (cs *claimService) Create(ctx context.Context, req *dto.CreateClaimRequest)
 	return res, nil
 }
 
-func (cs *claimService) GetAllWithPagination(ctx context.Context, req response.PaginationRequest) (dto.ClaimPaginationResponse, error) {
-	datas, err := cs.claimRepo.GetAllClaimsWithPagination(ctx, nil, req)
+func (cs *claimService) GetAllWithPagination(ctx context.Context, req response.PaginationRequest, filter dto.ClaimFilterRequest) (dto.ClaimPaginationResponse, error) {
+	datas, err := cs.claimRepo.GetAllClaimsWithPagination(ctx, nil, req, filter)
 	if err != nil {
-		return dto.ClaimPaginationResponse{}, fmt.Errorf("Failed to get all claims: %v\n", err)
+		return dto.ClaimPaginationResponse{}, fmt.Errorf("Failed to get all claims: %w", err)
+	}
+
+	claimIDs := make([]uuid.UUID, 0, len(datas.Claims))
+	for _, claim := range datas.Claims {
+		claimIDs = append(claimIDs, claim.ID)
+	}
+	commentCounts, err := cs.claimCommentRepo.CountByClaimIDs(ctx, nil, claimIDs)
+	if err != nil {
+		return dto.ClaimPaginationResponse{}, fmt.Errorf("Failed to count comments: %w", err)
+	}
+	reactionCounts, err := cs.reactionRepo.CountByClaimIDs(ctx, nil, claimIDs)
+	if err != nil {
+		return dto.ClaimPaginationResponse{}, fmt.Errorf("Failed to count reactions: %w", err)
 	}
 
 	claims := make([]*dto.ClaimResponse, 0, len(datas.Claims))
 	for _, claim := range datas.Claims {
+		reactions := reactionCounts[claim.ID]
+		if reactions == nil {
+			reactions = []dto.ReactionCountResponse{}
+		}
+
 		claims = append(claims, &dto.ClaimResponse{
 			ID:            claim.ID,
 			Event:         claim.Event,
@@ -128,6 +456,9 @@ func (cs *claimService) GetAllWithPagination(ctx context.Context, req response.P
 			ScreenshotURL: claim.ScreenshotURL,
 			ApproveCount:  claim.ApproveCount,
 			RejectCount:   claim.RejectCount,
+			IsImported:    claim.IsImported,
+			CommentCount:  commentCounts[claim.ID],
+			Reactions:     reactions,
 			ClaimedPlayer: dto.UserSimpleResponse{
 				ID:        claim.ClaimedPlayer.ID,
 				Username:  claim.ClaimedPlayer.Username,
@@ -159,10 +490,23 @@ func (cs *claimService) GetAllWithPagination(ctx context.Context, req response.P
 func (cs *claimService) GetDetailByID(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
 	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, id)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	commentCounts, err := cs.claimCommentRepo.CountByClaimIDs(ctx, nil, []uuid.UUID{claim.ID})
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to count comments: %w", err)
+	}
+	reactionCounts, err := cs.reactionRepo.CountByClaimIDs(ctx, nil, []uuid.UUID{claim.ID})
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to count reactions: %w", err)
+	}
+	reactions := reactionCounts[claim.ID]
+	if reactions == nil {
+		reactions = []dto.ReactionCountResponse{}
 	}
 
 	res := &dto.ClaimResponse{
@@ -174,6 +518,9 @@ func (cs *claimService) GetDetailByID(ctx context.Context, id *uuid.UUID) (*dto.
 		ScreenshotURL: claim.ScreenshotURL,
 		ApproveCount:  claim.ApproveCount,
 		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		CommentCount:  commentCounts[claim.ID],
+		Reactions:     reactions,
 		ClaimedPlayer: dto.UserSimpleResponse{
 			ID:        claim.ClaimedPlayer.ID,
 			Username:  claim.ClaimedPlayer.Username,
@@ -194,44 +541,76 @@ func (cs *claimService) GetDetailByID(ctx context.Context, id *uuid.UUID) (*dto.
 }
 
 func (cs *claimService) Update(ctx context.Context, req *dto.UpdateClaimRequest) (*dto.ClaimResponse, error) {
-	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, &req.ID)
+	actorID, err := getUserIDFromContext(ctx)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %v\n", err)
-	}
-	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %v\n", err)
+		return &dto.ClaimResponse{}, err
 	}
 
-	_, found, err = cs.userRepo.GetDetailByID(ctx, nil, &req.ReporterID)
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, &req.ID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get reporter by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed reporter not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+	if claim.ReporterID != actorID {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed only the reporter can edit this claim: %w", dto.ErrForbidden)
+	}
+	if claim.Status != entity.StatusPending {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim can only be edited while pending: %w", dto.ErrInvalidTransition)
 	}
+	before := *claim
 
-	_, found, err = cs.userRepo.GetDetailByID(ctx, nil, &req.ClaimedPlayerID)
+	claimedPlayer, found, err := cs.userRepo.GetDetailByID(ctx, nil, &req.ClaimedPlayerID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claimed player by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claimed player by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claimed player not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claimed player not found: %w", dto.ErrNotFound)
 	}
 
 	date, err := helper.ParseDateTime(req.MatchDate)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed parse date: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed parse date: %w: %w", dto.ErrValidationFailed, err)
 	}
 
 	claim.Event = req.Event
 	claim.MatchDate = date
 	claim.TotalPlayer = req.TotalPlayer
 	claim.ScreenshotURL = req.ScreenshotURL
-	claim.ClaimedPlayerID = req.ClaimedPlayerID
-	claim.ReporterID = req.ReporterID
+	claim.ClaimedPlayerID = claimedPlayer.ID
+	claim.ClaimedPlayer = *claimedPlayer
+
+	// the vote reset, the update and its revision land together or not at all
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var resetVotes []entity.Vote
+		if isSubstantiveEdit(&before, claim) {
+			votes, err := cs.resetVotes(ctx, tx, claim)
+			if err != nil {
+				return err
+			}
+			resetVotes = votes
+		}
+
+		if err := cs.claimRepo.Update(ctx, tx, claim); err != nil {
+			return fmt.Errorf("Failed to update claim: %w", err)
+		}
 
-	if err := cs.claimRepo.Update(ctx, nil, claim); err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to update claim: %v\n", err)
+		changes := diffClaim(&before, claim)
+		if len(resetVotes) > 0 {
+			changes = append(changes, votesResetChange(resetVotes))
+		}
+		if len(changes) > 0 {
+			if err := cs.recordRevision(ctx, tx, claim.ID, actorID, entity.RevisionUpdate, changes, ""); err != nil {
+				return err
+			}
+		}
+
+		message := fmt.Sprintf("Your vote on the %s claim for %s was reset because the claim was edited", claim.Event, claim.ClaimedPlayer.Username)
+		return cs.notifyVoteReset(ctx, tx, claim, resetVotes, actorID, message)
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
 	}
 
 	res := &dto.ClaimResponse{
@@ -243,6 +622,7 @@ func (cs *claimService) Update(ctx context.Context, req *dto.UpdateClaimRequest)
 		ScreenshotURL: claim.ScreenshotURL,
 		ApproveCount:  claim.ApproveCount,
 		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
 		ClaimedPlayer: dto.UserSimpleResponse{
 			ID:        claim.ClaimedPlayer.ID,
 			Username:  claim.ClaimedPlayer.Username,
@@ -263,17 +643,40 @@ func (cs *claimService) Update(ctx context.Context, req *dto.UpdateClaimRequest)
 }
 
 func (cs *claimService) DeleteByID(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
 	deletedClaim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, id)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+	role, _ := ctx.Value("role").(string)
+	if deletedClaim.ReporterID != actorID && role != constants.ENUM_ROLE_ADMIN {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed only the reporter can delete this claim: %w", dto.ErrForbidden)
 	}
+	// decided claims feed other players' stats, so only an admin may remove them
+	if role != constants.ENUM_ROLE_ADMIN && deletedClaim.Status != entity.StatusPending {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim can only be deleted while pending: %w", dto.ErrInvalidTransition)
+	}
+
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.claimRepo.DeleteByID(ctx, tx, id); err != nil {
+			return fmt.Errorf("Failed to delete claim by id: %w", err)
+		}
 
-	err = cs.claimRepo.DeleteByID(ctx, nil, id)
+		if err := cs.recordRevision(ctx, tx, deletedClaim.ID, actorID, entity.RevisionDelete, diffClaim(deletedClaim, nil), ""); err != nil {
+			return err
+		}
+
+		return cs.refreshStats(ctx, tx, deletedClaim)
+	})
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to delete claim by id: %v\n", err)
+		return &dto.ClaimResponse{}, err
 	}
 
 	res := &dto.ClaimResponse{
@@ -285,6 +688,7 @@ func (cs *claimService) DeleteByID(ctx context.Context, id *uuid.UUID) (*dto.Cla
 		ScreenshotURL: deletedClaim.ScreenshotURL,
 		ApproveCount:  deletedClaim.ApproveCount,
 		RejectCount:   deletedClaim.RejectCount,
+		IsImported:    deletedClaim.IsImported,
 		ClaimedPlayer: dto.UserSimpleResponse{
 			ID:        deletedClaim.ClaimedPlayer.ID,
 			Username:  deletedClaim.ClaimedPlayer.Username,
@@ -307,32 +711,27 @@ func (cs *claimService) DeleteByID(ctx context.Context, id *uuid.UUID) (*dto.Cla
 func (cs *claimService) Vote(ctx context.Context, req *dto.ClaimVoteRequest) (*dto.ClaimResponse, error) {
 	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, &req.ID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
 	}
 	if !found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
 	}
 
 	if claim.Status != entity.StatusPending {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim is over: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim is over: %w", dto.ErrInvalidTransition)
 	}
 
-	token := ctx.Value("Authorization").(string)
-	userIDString, err := cs.jwt.GetUserIDByToken(token)
-	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get user ID by token: %v\n", err)
-	}
-	userID, err := uuid.Parse(userIDString)
+	userID, err := getUserIDFromContext(ctx)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed parse id from string to uuid: %v\n", err)
+		return &dto.ClaimResponse{}, err
 	}
 
 	_, found, err = cs.voteRepo.GetByClaimIDAndVoterID(ctx, nil, &claim.ID, &userID)
 	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get vote by claim id and voter ID: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get vote by claim id and voter ID: %w", err)
 	}
 	if found {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed already vote: %v\n", err)
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed already vote: %w", dto.ErrAlreadyExists)
 	}
 
 	if req.Type == string(entity.VoteApprove) {
@@ -347,27 +746,48 @@ func (cs *claimService) Vote(ctx context.Context, req *dto.ClaimVoteRequest) (*d
 		VoterID: userID,
 		Type:    entity.VoteType(req.Type),
 	}
-	if err := cs.voteRepo.Create(ctx, nil, vote); err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to create vote: %v\n", err)
-	}
 
-	totalUser, err := cs.userRepo.Count(ctx, nil)
-	if err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to count total user: %v\n", err)
-	}
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.voteRepo.Create(ctx, tx, vote); err != nil {
+			return fmt.Errorf("Failed to create vote: %w", err)
+		}
 
-	minApprove := (totalUser / 2) + 1
-	if claim.ApproveCount >= int(minApprove) {
-		claim.Status = entity.StatusFinalApproved
-	}
-	remainingVote := int(totalUser) - (claim.ApproveCount + claim.RejectCount)
-	maxPossibleApprove := claim.ApproveCount + remainingVote
-	if maxPossibleApprove < int(minApprove) {
-		claim.Status = entity.StatusFinalRejected
-	}
+		status := claim.Status
+		if isVoteWeightingEnabled() {
+			weighted, err := cs.resolveWeightedVotes(ctx, tx, claim)
+			if err != nil {
+				return err
+			}
+			status = weighted
+		} else {
+			totalUser, err := cs.userRepo.Count(ctx, tx)
+			if err != nil {
+				return fmt.Errorf("Failed to count total user: %w", err)
+			}
 
-	if err := cs.claimRepo.Update(ctx, nil, claim); err != nil {
-		return &dto.ClaimResponse{}, fmt.Errorf("Failed to update claim: %v\n", err)
+			minApprove := (totalUser / 2) + 1
+			if claim.ApproveCount >= int(minApprove) {
+				status = entity.StatusFinalApproved
+			}
+			remainingVote := int(totalUser) - (claim.ApproveCount + claim.RejectCount)
+			maxPossibleApprove := claim.ApproveCount + remainingVote
+			if maxPossibleApprove < int(minApprove) {
+				status = entity.StatusFinalRejected
+			}
+		}
+
+		if status != claim.Status {
+			return cs.transitionClaim(ctx, tx, claim, status, userID, "")
+		}
+
+		if err := cs.claimRepo.Update(ctx, tx, claim); err != nil {
+			return fmt.Errorf("Failed to update claim: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
 	}
 
 	res := &dto.ClaimResponse{
@@ -379,6 +799,7 @@ func (cs *claimService) Vote(ctx context.Context, req *dto.ClaimVoteRequest) (*d
 		ScreenshotURL: claim.ScreenshotURL,
 		ApproveCount:  claim.ApproveCount,
 		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
 		ClaimedPlayer: dto.UserSimpleResponse{
 			ID:        claim.ClaimedPlayer.ID,
 			Username:  claim.ClaimedPlayer.Username,
@@ -401,7 +822,7 @@ func (cs *claimService) Vote(ctx context.Context, req *dto.ClaimVoteRequest) (*d
 func (cs *claimService) GetAllVotesByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.ClaimVoteResponse, error) {
 	datas, err := cs.voteRepo.GetAllByClaimID(ctx, nil, claimID)
 	if err != nil {
-		return []dto.ClaimVoteResponse{}, fmt.Errorf("Failed to get all votes by claim ID: %v\n", err)
+		return []dto.ClaimVoteResponse{}, fmt.Errorf("Failed to get all votes by claim ID: %w", err)
 	}
 
 	votes := make([]dto.ClaimVoteResponse, 0, len(datas))
@@ -419,3 +840,624 @@ func (cs *claimService) GetAllVotesByClaimID(ctx context.Context, claimID *uuid.
 
 	return votes, nil
 }
+
+// GetHistoryByClaimID returns the claim's audit trail. Trashed and purged
+// claims keep theirs, so only a claim that never existed is not found.
+func (cs *claimService) GetHistoryByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.ClaimRevisionResponse, error) {
+	datas, err := cs.claimRevisionRepo.GetAllByClaimID(ctx, nil, claimID)
+	if err != nil {
+		return []dto.ClaimRevisionResponse{}, fmt.Errorf("Failed to get all revisions by claim ID: %w", err)
+	}
+	if len(datas) == 0 {
+		exists, err := cs.claimRepo.ExistsByID(ctx, nil, claimID)
+		if err != nil {
+			return []dto.ClaimRevisionResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+		}
+		if !exists {
+			return []dto.ClaimRevisionResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+		}
+	}
+
+	revisions := make([]dto.ClaimRevisionResponse, 0, len(datas))
+	for _, revision := range datas {
+		changes := []dto.ClaimFieldChange{}
+		if err := json.Unmarshal([]byte(revision.Changes), &changes); err != nil {
+			return []dto.ClaimRevisionResponse{}, fmt.Errorf("Failed to unmarshal claim changes: %w", err)
+		}
+
+		revisions = append(revisions, dto.ClaimRevisionResponse{
+			ID:     revision.ID,
+			Action: revision.Action,
+			Actor: dto.UserSimpleResponse{
+				ID:        revision.Actor.ID,
+				Username:  revision.Actor.Username,
+				AvatarURL: revision.Actor.AvatarURL,
+			},
+			Changes:   changes,
+			Reason:    revision.Reason,
+			CreatedAt: revision.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return revisions, nil
+}
+
+func (cs *claimService) Reopen(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return cs.transitionClaim(ctx, tx, claim, entity.StatusPending, actorID, "")
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	res := &dto.ClaimResponse{
+		ID:            claim.ID,
+		Event:         claim.Event,
+		Status:        claim.Status,
+		MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+		TotalPlayer:   claim.TotalPlayer,
+		ScreenshotURL: claim.ScreenshotURL,
+		ApproveCount:  claim.ApproveCount,
+		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		ClaimedPlayer: dto.UserSimpleResponse{
+			ID:        claim.ClaimedPlayer.ID,
+			Username:  claim.ClaimedPlayer.Username,
+			AvatarURL: claim.ClaimedPlayer.AvatarURL,
+		},
+		Reporter: dto.UserSimpleResponse{
+			ID:        claim.Reporter.ID,
+			Username:  claim.Reporter.Username,
+			AvatarURL: claim.Reporter.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+// Cancel withdraws a pending claim on behalf of its reporter. Unlike a delete
+// the claim and its votes stay visible, just out of stats and the default list.
+func (cs *claimService) Cancel(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+	if claim.ReporterID != actorID {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed only the reporter can cancel this claim: %w", dto.ErrForbidden)
+	}
+
+	// votes are kept so the history shows how far the claim got
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return cs.transitionClaim(ctx, tx, claim, entity.StatusCancelled, actorID, "")
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	res := &dto.ClaimResponse{
+		ID:            claim.ID,
+		Event:         claim.Event,
+		Status:        claim.Status,
+		MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+		TotalPlayer:   claim.TotalPlayer,
+		ScreenshotURL: claim.ScreenshotURL,
+		ApproveCount:  claim.ApproveCount,
+		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		ClaimedPlayer: dto.UserSimpleResponse{
+			ID:        claim.ClaimedPlayer.ID,
+			Username:  claim.ClaimedPlayer.Username,
+			AvatarURL: claim.ClaimedPlayer.AvatarURL,
+		},
+		Reporter: dto.UserSimpleResponse{
+			ID:        claim.Reporter.ID,
+			Username:  claim.Reporter.Username,
+			AvatarURL: claim.Reporter.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+// Resolve lets an admin decide a pending claim the group settled elsewhere.
+// It goes through the same transition as a deciding vote, so stats and
+// achievements update exactly as they would have; the reason is audited.
+func (cs *claimService) Resolve(ctx context.Context, req *dto.ResolveClaimRequest) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, &req.ID)
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	// disputed claims are settled by their appeal, not by an override
+	if claim.Status != entity.StatusPending {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed only pending claims can be resolved: %w", dto.ErrInvalidTransition)
+	}
+
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return cs.transitionClaim(ctx, tx, claim, req.Status, actorID, req.Reason)
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	res := &dto.ClaimResponse{
+		ID:            claim.ID,
+		Event:         claim.Event,
+		Status:        claim.Status,
+		MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+		TotalPlayer:   claim.TotalPlayer,
+		ScreenshotURL: claim.ScreenshotURL,
+		ApproveCount:  claim.ApproveCount,
+		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		ClaimedPlayer: dto.UserSimpleResponse{
+			ID:        claim.ClaimedPlayer.ID,
+			Username:  claim.ClaimedPlayer.Username,
+			AvatarURL: claim.ClaimedPlayer.AvatarURL,
+		},
+		Reporter: dto.UserSimpleResponse{
+			ID:        claim.Reporter.ID,
+			Username:  claim.Reporter.Username,
+			AvatarURL: claim.Reporter.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+func (cs *claimService) GetAllDeletedWithPagination(ctx context.Context, req response.PaginationRequest) (dto.ClaimPaginationResponse, error) {
+	datas, err := cs.claimRepo.GetAllDeletedClaimsWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.ClaimPaginationResponse{}, fmt.Errorf("Failed to get all deleted claims: %w", err)
+	}
+
+	claims := make([]*dto.ClaimResponse, 0, len(datas.Claims))
+	for _, claim := range datas.Claims {
+		deletedAt := claim.DeletedAt.Time.Format("2006-01-02 15:04:05")
+		claims = append(claims, &dto.ClaimResponse{
+			ID:            claim.ID,
+			Event:         claim.Event,
+			Status:        claim.Status,
+			MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+			TotalPlayer:   claim.TotalPlayer,
+			ScreenshotURL: claim.ScreenshotURL,
+			ApproveCount:  claim.ApproveCount,
+			RejectCount:   claim.RejectCount,
+			IsImported:    claim.IsImported,
+			ClaimedPlayer: dto.UserSimpleResponse{
+				ID:        claim.ClaimedPlayer.ID,
+				Username:  claim.ClaimedPlayer.Username,
+				AvatarURL: claim.ClaimedPlayer.AvatarURL,
+			},
+			Reporter: dto.UserSimpleResponse{
+				ID:        claim.Reporter.ID,
+				Username:  claim.Reporter.Username,
+				AvatarURL: claim.Reporter.AvatarURL,
+			},
+			TimestampTemplate: dto.TimestampTemplate{
+				CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+				DeletedAt: &deletedAt,
+			},
+		})
+	}
+
+	return dto.ClaimPaginationResponse{
+		Data: claims,
+		PaginationResponse: response.PaginationResponse{
+			Page:    datas.Page,
+			PerPage: datas.PerPage,
+			MaxPage: datas.MaxPage,
+			Count:   datas.Count,
+		},
+	}, nil
+}
+
+func (cs *claimService) Restore(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	claim, found, err := cs.claimRepo.GetDeletedByID(ctx, nil, id)
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get deleted claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed deleted claim not found: %w", dto.ErrNotFound)
+	}
+
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.claimRepo.RestoreByID(ctx, tx, id); err != nil {
+			return fmt.Errorf("Failed to restore claim: %w", err)
+		}
+
+		if err := cs.recordRevision(ctx, tx, claim.ID, actorID, entity.RevisionRestore, diffClaim(nil, claim), ""); err != nil {
+			return err
+		}
+
+		return cs.refreshStats(ctx, tx, claim)
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	res := &dto.ClaimResponse{
+		ID:            claim.ID,
+		Event:         claim.Event,
+		Status:        claim.Status,
+		MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+		TotalPlayer:   claim.TotalPlayer,
+		ScreenshotURL: claim.ScreenshotURL,
+		ApproveCount:  claim.ApproveCount,
+		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		ClaimedPlayer: dto.UserSimpleResponse{
+			ID:        claim.ClaimedPlayer.ID,
+			Username:  claim.ClaimedPlayer.Username,
+			AvatarURL: claim.ClaimedPlayer.AvatarURL,
+		},
+		Reporter: dto.UserSimpleResponse{
+			ID:        claim.Reporter.ID,
+			Username:  claim.Reporter.Username,
+			AvatarURL: claim.Reporter.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+// Purge hard-deletes a trashed claim. Its revisions cascade with it, so the
+// purge itself is only kept in the log, written before the delete runs.
+func (cs *claimService) Purge(ctx context.Context, id *uuid.UUID) (*dto.ClaimResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+
+	claim, found, err := cs.claimRepo.GetDeletedByID(ctx, nil, id)
+	if err != nil {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed to get deleted claim by id: %w", err)
+	}
+	if !found {
+		return &dto.ClaimResponse{}, fmt.Errorf("Failed deleted claim not found: %w", dto.ErrNotFound)
+	}
+
+	// the purge revision outlives the claim along with the rest of its trail
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.recordRevision(ctx, tx, claim.ID, actorID, entity.RevisionPurge, diffClaim(claim, nil), ""); err != nil {
+			return err
+		}
+
+		if err := cs.claimRepo.PurgeByID(ctx, tx, id); err != nil {
+			return fmt.Errorf("Failed to purge claim: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &dto.ClaimResponse{}, err
+	}
+	logger.FromContext(ctx, cs.logger).Warn("claim purged",
+		zap.String("claim_id", claim.ID.String()),
+		zap.String("purged_by", actorID.String()),
+		zap.Time("deleted_at", claim.DeletedAt.Time),
+	)
+
+	deletedAt := claim.DeletedAt.Time.Format("2006-01-02 15:04:05")
+	res := &dto.ClaimResponse{
+		ID:            claim.ID,
+		Event:         claim.Event,
+		Status:        claim.Status,
+		MatchDate:     claim.MatchDate.Format("2006-01-02 15:04:05"),
+		TotalPlayer:   claim.TotalPlayer,
+		ScreenshotURL: claim.ScreenshotURL,
+		ApproveCount:  claim.ApproveCount,
+		RejectCount:   claim.RejectCount,
+		IsImported:    claim.IsImported,
+		ClaimedPlayer: dto.UserSimpleResponse{
+			ID:        claim.ClaimedPlayer.ID,
+			Username:  claim.ClaimedPlayer.Username,
+			AvatarURL: claim.ClaimedPlayer.AvatarURL,
+		},
+		Reporter: dto.UserSimpleResponse{
+			ID:        claim.Reporter.ID,
+			Username:  claim.Reporter.Username,
+			AvatarURL: claim.Reporter.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: claim.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: claim.UpdatedAt.Format("2006-01-02 15:04:05"),
+			DeletedAt: &deletedAt,
+		},
+	}
+
+	return res, nil
+}
+
+func (cs *claimService) OpenDispute(ctx context.Context, req *dto.CreateDisputeRequest) (*dto.DisputeResponse, error) {
+	actorID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.DisputeResponse{}, err
+	}
+
+	appellant, found, err := cs.userRepo.GetDetailByID(ctx, nil, &actorID)
+	if err != nil {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed to get appellant by id: %w", err)
+	}
+	if !found {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed appellant not found: %w", dto.ErrNotFound)
+	}
+
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, nil, &req.ClaimID)
+	if err != nil {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+	if err := checkClaimTransition(claim.Status, entity.StatusDisputed); err != nil {
+		return &dto.DisputeResponse{}, err
+	}
+
+	dispute := &entity.Dispute{
+		Reason:         req.Reason,
+		Status:         entity.DisputeOpen,
+		PreviousStatus: claim.Status,
+		ClaimID:        claim.ID,
+		AppellantID:    appellant.ID,
+		Appellant:      *appellant,
+	}
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.disputeRepo.Create(ctx, tx, dispute); err != nil {
+			return fmt.Errorf("Failed to create dispute: %w", err)
+		}
+
+		// the claim stops counting towards stats until the appeal is decided
+		if err := cs.transitionClaim(ctx, tx, claim, entity.StatusDisputed, actorID, ""); err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("%s disputed the %s claim for %s: %s", appellant.Username, claim.Event, claim.ClaimedPlayer.Username, dispute.Reason)
+		return cs.notify(ctx, tx, claim, entity.NotificationDisputeOpened, message, uniqueUserIDs(actorID, claim.ReporterID, claim.ClaimedPlayerID)...)
+	})
+	if err != nil {
+		return &dto.DisputeResponse{}, err
+	}
+
+	res := &dto.DisputeResponse{
+		ID:             dispute.ID,
+		ClaimID:        dispute.ClaimID,
+		Reason:         dispute.Reason,
+		Status:         dispute.Status,
+		PreviousStatus: dispute.PreviousStatus,
+		UpholdCount:    dispute.UpholdCount,
+		DismissCount:   dispute.DismissCount,
+		Appellant: dto.UserSimpleResponse{
+			ID:        dispute.Appellant.ID,
+			Username:  dispute.Appellant.Username,
+			AvatarURL: dispute.Appellant.AvatarURL,
+		},
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: dispute.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: dispute.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+func (cs *claimService) VoteDispute(ctx context.Context, req *dto.DisputeVoteRequest) (*dto.DisputeResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.DisputeResponse{}, err
+	}
+
+	dispute, found, err := cs.disputeRepo.GetDetailByID(ctx, nil, &req.DisputeID)
+	if err != nil {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed to get dispute by id: %w", err)
+	}
+	if !found || dispute.ClaimID != req.ClaimID {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed dispute not found: %w", dto.ErrNotFound)
+	}
+	if dispute.Status != entity.DisputeOpen {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed dispute is over: %w", dto.ErrInvalidTransition)
+	}
+
+	_, found, err = cs.disputeVoteRepo.GetByDisputeIDAndVoterID(ctx, nil, &dispute.ID, &userID)
+	if err != nil {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed to get dispute vote by dispute id and voter ID: %w", err)
+	}
+	if found {
+		return &dto.DisputeResponse{}, fmt.Errorf("Failed already vote: %w", dto.ErrAlreadyExists)
+	}
+
+	if req.Type == string(entity.VoteApprove) {
+		dispute.UpholdCount++
+	} else {
+		dispute.DismissCount++
+	}
+
+	vote := &entity.DisputeVote{
+		DisputeID: dispute.ID,
+		VoterID:   userID,
+		Type:      entity.VoteType(req.Type),
+	}
+
+	err = cs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := cs.disputeVoteRepo.Create(ctx, tx, vote); err != nil {
+			return fmt.Errorf("Failed to create dispute vote: %w", err)
+		}
+
+		totalUser, err := cs.userRepo.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("Failed to count total user: %w", err)
+		}
+
+		minUphold := (int(totalUser)*disputeUpholdNumerator + disputeUpholdDenominator - 1) / disputeUpholdDenominator
+		if dispute.UpholdCount >= minUphold {
+			dispute.Status = entity.DisputeUpheld
+		}
+		remainingVote := int(totalUser) - (dispute.UpholdCount + dispute.DismissCount)
+		if dispute.UpholdCount+remainingVote < minUphold {
+			dispute.Status = entity.DisputeDismissed
+		}
+
+		if dispute.Status != entity.DisputeOpen {
+			now := time.Now()
+			dispute.ResolvedAt = &now
+		}
+
+		if err := cs.disputeRepo.Update(ctx, tx, dispute); err != nil {
+			return fmt.Errorf("Failed to update dispute: %w", err)
+		}
+
+		if dispute.Status != entity.DisputeOpen {
+			return cs.closeDispute(ctx, tx, dispute, userID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &dto.DisputeResponse{}, err
+	}
+
+	var resolvedAt *string
+	if dispute.ResolvedAt != nil {
+		formatted := dispute.ResolvedAt.Format("2006-01-02 15:04:05")
+		resolvedAt = &formatted
+	}
+
+	res := &dto.DisputeResponse{
+		ID:             dispute.ID,
+		ClaimID:        dispute.ClaimID,
+		Reason:         dispute.Reason,
+		Status:         dispute.Status,
+		PreviousStatus: dispute.PreviousStatus,
+		UpholdCount:    dispute.UpholdCount,
+		DismissCount:   dispute.DismissCount,
+		Appellant: dto.UserSimpleResponse{
+			ID:        dispute.Appellant.ID,
+			Username:  dispute.Appellant.Username,
+			AvatarURL: dispute.Appellant.AvatarURL,
+		},
+		ResolvedAt: resolvedAt,
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: dispute.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: dispute.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+
+	return res, nil
+}
+
+// closeDispute applies a decided appeal to its claim: an upheld appeal flips
+// the original outcome, a dismissed one restores it.
+func (cs *claimService) closeDispute(ctx context.Context, tx *gorm.DB, dispute *entity.Dispute, actorID uuid.UUID) error {
+	claim, found, err := cs.claimRepo.GetDetailByID(ctx, tx, &dispute.ClaimID)
+	if err != nil {
+		return fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	status := dispute.PreviousStatus
+	if dispute.Status == entity.DisputeUpheld {
+		if dispute.PreviousStatus == entity.StatusFinalApproved {
+			status = entity.StatusFinalRejected
+		} else {
+			status = entity.StatusFinalApproved
+		}
+	}
+
+	if err := cs.transitionClaim(ctx, tx, claim, status, actorID, ""); err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("The dispute on the %s claim for %s was %s, the claim is now %s", claim.Event, claim.ClaimedPlayer.Username, strings.ToLower(string(dispute.Status)), claim.Status)
+	return cs.notify(ctx, tx, claim, entity.NotificationDisputeClosed, message, uniqueUserIDs(uuid.Nil, dispute.AppellantID, claim.ReporterID, claim.ClaimedPlayerID)...)
+}
+
+func (cs *claimService) GetAllDisputesByClaimID(ctx context.Context, claimID *uuid.UUID) ([]dto.DisputeResponse, error) {
+	datas, err := cs.disputeRepo.GetAllByClaimID(ctx, nil, claimID)
+	if err != nil {
+		return []dto.DisputeResponse{}, fmt.Errorf("Failed to get all disputes by claim ID: %w", err)
+	}
+
+	disputes := make([]dto.DisputeResponse, 0, len(datas))
+	for _, dispute := range datas {
+		var resolvedAt *string
+		if dispute.ResolvedAt != nil {
+			formatted := dispute.ResolvedAt.Format("2006-01-02 15:04:05")
+			resolvedAt = &formatted
+		}
+
+		disputes = append(disputes, dto.DisputeResponse{
+			ID:             dispute.ID,
+			ClaimID:        dispute.ClaimID,
+			Reason:         dispute.Reason,
+			Status:         dispute.Status,
+			PreviousStatus: dispute.PreviousStatus,
+			UpholdCount:    dispute.UpholdCount,
+			DismissCount:   dispute.DismissCount,
+			Appellant: dto.UserSimpleResponse{
+				ID:        dispute.Appellant.ID,
+				Username:  dispute.Appellant.Username,
+				AvatarURL: dispute.Appellant.AvatarURL,
+			},
+			ResolvedAt: resolvedAt,
+			TimestampTemplate: dto.TimestampTemplate{
+				CreatedAt: dispute.CreatedAt.Format("2006-01-02 15:04:05"),
+				UpdatedAt: dispute.UpdatedAt.Format("2006-01-02 15:04:05"),
+			},
+		})
+	}
+
+	return disputes, nil
+}
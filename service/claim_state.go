@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// claimTransitions is the claim lifecycle: each status maps to the statuses it
+// may move to. Anything not listed here is rejected, and statuses without an
+// entry are terminal.
+var claimTransitions = map[entity.ClaimStatus][]entity.ClaimStatus{
+	entity.StatusPending:       {entity.StatusFinalApproved, entity.StatusFinalRejected, entity.StatusCancelled, entity.StatusExpired},
+	entity.StatusFinalApproved: {entity.StatusPending, entity.StatusDisputed},
+	entity.StatusFinalRejected: {entity.StatusPending, entity.StatusDisputed},
+	entity.StatusDisputed:      {entity.StatusFinalApproved, entity.StatusFinalRejected},
+}
+
+// claimTransition describes one status change while its hooks run. The claim
+// already carries the new status; before is a copy taken just ahead of it.
+// Reason is only set when an admin overrides the vote, resetVotes only when
+// the claim goes back to PENDING.
+type claimTransition struct {
+	tx         *gorm.DB
+	claim      *entity.Claim
+	before     entity.Claim
+	actorID    uuid.UUID
+	reason     string
+	resetVotes []entity.Vote
+}
+
+func (ct *claimTransition) from() entity.ClaimStatus {
+	return ct.before.Status
+}
+
+func (ct *claimTransition) to() entity.ClaimStatus {
+	return ct.claim.Status
+}
+
+// claimTransitionHooks run in order after every persisted status change.
+var claimTransitionHooks = []func(cs *claimService, ctx context.Context, ct *claimTransition) error{
+	(*claimService).auditTransition,
+	(*claimService).refreshTransitionStats,
+	(*claimService).awardTransitionAchievements,
+	(*claimService).notifyTransition,
+}
+
+func canTransitionClaim(from, to entity.ClaimStatus) bool {
+	for _, allowed := range claimTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkClaimTransition(from, to entity.ClaimStatus) error {
+	if !canTransitionClaim(from, to) {
+		return fmt.Errorf("Failed claim cannot move from %s to %s: %w", from, to, dto.ErrInvalidTransition)
+	}
+
+	return nil
+}
+
+// isFinalStatus reports whether a claim with this status has been decided and
+// counts towards stats and achievements.
+func isFinalStatus(status entity.ClaimStatus) bool {
+	return status == entity.StatusFinalApproved || status == entity.StatusFinalRejected
+}
+
+// transitionClaim is the only way a claim's status changes after creation. It
+// checks the move against claimTransitions, saves the claim (including any
+// other pending field changes) and then runs claimTransitionHooks.
+func (cs *claimService) transitionClaim(ctx context.Context, tx *gorm.DB, claim *entity.Claim, to entity.ClaimStatus, actorID uuid.UUID, reason string) error {
+	if err := checkClaimTransition(claim.Status, to); err != nil {
+		return err
+	}
+
+	ct := &claimTransition{
+		tx:      tx,
+		claim:   claim,
+		before:  *claim,
+		actorID: actorID,
+		reason:  reason,
+	}
+
+	// a reopened claim is voted on from scratch, otherwise the old tally either
+	// blocks every voter or decides it again on the very next vote
+	if to == entity.StatusPending {
+		resetVotes, err := cs.resetVotes(ctx, tx, claim)
+		if err != nil {
+			return err
+		}
+		ct.resetVotes = resetVotes
+	}
+	claim.Status = to
+
+	if err := cs.claimRepo.Update(ctx, tx, claim); err != nil {
+		return fmt.Errorf("Failed to update claim: %w", err)
+	}
+
+	for _, hook := range claimTransitionHooks {
+		if err := hook(cs, ctx, ct); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cs *claimService) auditTransition(ctx context.Context, ct *claimTransition) error {
+	changes := diffClaim(&ct.before, ct.claim)
+	if len(ct.resetVotes) > 0 {
+		changes = append(changes, votesResetChange(ct.resetVotes))
+	}
+
+	return cs.recordRevision(ctx, ct.tx, ct.claim.ID, ct.actorID, entity.RevisionStatusChange, changes, ct.reason)
+}
+
+// refreshTransitionStats recomputes stats when the claim starts or stops
+// counting towards them. Only an approval can be applied on top of the stored
+// stats; reopening or overturning a result needs the full rescan.
+func (cs *claimService) refreshTransitionStats(ctx context.Context, ct *claimTransition) error {
+	if ct.to() == entity.StatusFinalApproved {
+		return cs.finalizeStats(ctx, ct.tx, ct.claim)
+	}
+	if isFinalStatus(ct.to()) {
+		return cs.refreshStats(ctx, ct.tx, ct.claim)
+	}
+
+	return cs.refreshStats(ctx, ct.tx, &ct.before)
+}
+
+func (cs *claimService) awardTransitionAchievements(ctx context.Context, ct *claimTransition) error {
+	return cs.awardAchievements(ctx, ct.tx, ct.claim)
+}
+
+// notifyTransition tells the people behind a claim about changes an admin made
+// to it. Disputes send their own notifications since those carry the appeal's
+// reason and outcome.
+func (cs *claimService) notifyTransition(ctx context.Context, ct *claimTransition) error {
+	switch {
+	case ct.reason != "" && isFinalStatus(ct.to()):
+		message := fmt.Sprintf("An admin resolved the %s claim for %s as %s: %s", ct.claim.Event, ct.claim.ClaimedPlayer.Username, ct.to(), ct.reason)
+		return cs.notify(ctx, ct.tx, ct.claim, entity.NotificationClaimResolved, message, uniqueUserIDs(ct.actorID, ct.claim.ReporterID, ct.claim.ClaimedPlayerID)...)
+	case ct.to() == entity.StatusPending && isFinalStatus(ct.from()):
+		if ct.claim.ReporterID != ct.actorID {
+			message := fmt.Sprintf("Your %s claim for %s was reopened by an admin and can be edited again", ct.claim.Event, ct.claim.ClaimedPlayer.Username)
+			if err := cs.notify(ctx, ct.tx, ct.claim, entity.NotificationClaimReopened, message, ct.claim.ReporterID); err != nil {
+				return err
+			}
+		}
+
+		message := fmt.Sprintf("Your vote on the %s claim for %s was reset because the claim was reopened", ct.claim.Event, ct.claim.ClaimedPlayer.Username)
+		return cs.notifyVoteReset(ctx, ct.tx, ct.claim, ct.resetVotes, ct.actorID, message)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Amierza/mc-kalak-backend/apperror"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// stubClaimRepository records the statuses claims were saved with; any other
+// repository method panics through the nil embedded interface.
+type stubClaimRepository struct {
+	repository.IClaimRepository
+	saved []entity.ClaimStatus
+}
+
+func (r *stubClaimRepository) Update(ctx context.Context, tx *gorm.DB, claim *entity.Claim) error {
+	r.saved = append(r.saved, claim.Status)
+	return nil
+}
+
+func TestCheckClaimTransition(t *testing.T) {
+	tests := []struct {
+		from    entity.ClaimStatus
+		to      entity.ClaimStatus
+		allowed bool
+	}{
+		{entity.StatusPending, entity.StatusFinalApproved, true},
+		{entity.StatusPending, entity.StatusFinalRejected, true},
+		{entity.StatusPending, entity.StatusCancelled, true},
+		{entity.StatusPending, entity.StatusExpired, true},
+		{entity.StatusPending, entity.StatusPending, false},
+		{entity.StatusPending, entity.StatusDisputed, false},
+		{entity.StatusFinalApproved, entity.StatusPending, true},
+		{entity.StatusFinalApproved, entity.StatusDisputed, true},
+		{entity.StatusFinalApproved, entity.StatusFinalRejected, false},
+		{entity.StatusFinalRejected, entity.StatusPending, true},
+		{entity.StatusFinalRejected, entity.StatusDisputed, true},
+		{entity.StatusFinalRejected, entity.StatusFinalApproved, false},
+		{entity.StatusDisputed, entity.StatusFinalApproved, true},
+		{entity.StatusDisputed, entity.StatusFinalRejected, true},
+		{entity.StatusDisputed, entity.StatusPending, false},
+		{entity.StatusCancelled, entity.StatusPending, false},
+		{entity.StatusCancelled, entity.StatusFinalApproved, false},
+		{entity.StatusExpired, entity.StatusPending, false},
+		{entity.StatusExpired, entity.StatusFinalApproved, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			err := checkClaimTransition(tt.from, tt.to)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("got error %v, want transition allowed", err)
+				}
+				return
+			}
+
+			if code := apperror.CodeOf(err); code != apperror.CodeInvalidTransition {
+				t.Fatalf("got code %q (err %v), want %q", code, err, apperror.CodeInvalidTransition)
+			}
+		})
+	}
+}
+
+func TestTransitionClaimRejectsDisallowedMove(t *testing.T) {
+	tests := []struct {
+		from entity.ClaimStatus
+		to   entity.ClaimStatus
+	}{
+		{entity.StatusCancelled, entity.StatusPending},
+		{entity.StatusExpired, entity.StatusFinalApproved},
+		{entity.StatusDisputed, entity.StatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			claimRepo := &stubClaimRepository{}
+			cs := &claimService{claimRepo: claimRepo}
+			claim := &entity.Claim{ID: uuid.New(), Status: tt.from}
+
+			err := cs.transitionClaim(context.Background(), nil, claim, tt.to, uuid.New(), "")
+			if code := apperror.CodeOf(err); code != apperror.CodeInvalidTransition {
+				t.Fatalf("got code %q (err %v), want %q", code, err, apperror.CodeInvalidTransition)
+			}
+			if claim.Status != tt.from {
+				t.Errorf("claim status = %s, want it left at %s", claim.Status, tt.from)
+			}
+			if len(claimRepo.saved) != 0 {
+				t.Errorf("claim was saved %d times, want none", len(claimRepo.saved))
+			}
+		})
+	}
+}
+
+func TestTransitionClaimRunsHooksInOrder(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	tests := []struct {
+		name    string
+		failAt  int
+		wantRun []string
+		wantErr error
+	}{
+		{
+			name:    "all hooks run",
+			failAt:  -1,
+			wantRun: []string{"first", "second", "third"},
+		},
+		{
+			name:    "a failing hook stops the rest",
+			failAt:  1,
+			wantRun: []string{"first", "second"},
+			wantErr: errHook,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimRepo := &stubClaimRepository{}
+			cs := &claimService{claimRepo: claimRepo}
+			claim := &entity.Claim{ID: uuid.New(), Status: entity.StatusPending}
+
+			var run []string
+			hook := func(name string, i int) func(*claimService, context.Context, *claimTransition) error {
+				return func(_ *claimService, _ context.Context, ct *claimTransition) error {
+					if len(claimRepo.saved) != 1 {
+						t.Errorf("hook %s ran before the claim was saved", name)
+					}
+					if ct.from() != entity.StatusPending || ct.to() != entity.StatusFinalApproved {
+						t.Errorf("hook %s saw %s -> %s, want %s -> %s", name, ct.from(), ct.to(), entity.StatusPending, entity.StatusFinalApproved)
+					}
+
+					run = append(run, name)
+					if i == tt.failAt {
+						return errHook
+					}
+					return nil
+				}
+			}
+
+			hooks := claimTransitionHooks
+			t.Cleanup(func() { claimTransitionHooks = hooks })
+			claimTransitionHooks = []func(*claimService, context.Context, *claimTransition) error{
+				hook("first", 0),
+				hook("second", 1),
+				hook("third", 2),
+			}
+
+			err := cs.transitionClaim(context.Background(), nil, claim, entity.StatusFinalApproved, uuid.New(), "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(run, tt.wantRun) {
+				t.Errorf("hooks ran as %v, want %v", run, tt.wantRun)
+			}
+			if !reflect.DeepEqual(claimRepo.saved, []entity.ClaimStatus{entity.StatusFinalApproved}) {
+				t.Errorf("claim saved as %v, want [%s]", claimRepo.saved, entity.StatusFinalApproved)
+			}
+		})
+	}
+}
+
+// The revision has to be written before stats and achievements are derived
+// from the claim, and people are only notified once all of that succeeded.
+func TestClaimTransitionHooksOrder(t *testing.T) {
+	want := []string{
+		"auditTransition",
+		"refreshTransitionStats",
+		"awardTransitionAchievements",
+		"notifyTransition",
+	}
+
+	got := make([]string, 0, len(claimTransitionHooks))
+	for _, hook := range claimTransitionHooks {
+		name := runtime.FuncForPC(reflect.ValueOf(hook).Pointer()).Name()
+		got = append(got, name[strings.LastIndex(name, ".")+1:])
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("claimTransitionHooks = %v, want %v", got, want)
+	}
+}
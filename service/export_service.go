@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Amierza/mc-kalak-backend/constants"
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"go.uber.org/zap"
+)
+
+// exportFlushEvery is how many CSV rows are buffered before they are pushed
+// to the writer.
+const exportFlushEvery = 200
+
+// csvFormulaPrefixes are the leading characters that make spreadsheet apps
+// evaluate a cell as a formula.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+type (
+	IExportService interface {
+		ExportClaims(ctx context.Context, req *dto.ExportClaimsRequest, w io.Writer) error
+		ExportStats(ctx context.Context, req *dto.ExportStatsRequest, w io.Writer) error
+	}
+
+	exportService struct {
+		exportRepo repository.IExportRepository
+		logger     *zap.Logger
+	}
+)
+
+func NewExportService(exportRepo repository.IExportRepository, logger *zap.Logger) *exportService {
+	return &exportService{
+		exportRepo: exportRepo,
+		logger:     logger,
+	}
+}
+
+var (
+	claimExportHeader = []string{"id", "match_date", "event", "status", "total_player", "claimed_player", "reporter", "approve_count", "reject_count", "approved_by", "rejected_by", "is_imported", "created_at"}
+
+	playerStatExportHeader = []string{"player_id", "username", "total_match", "king_count", "kong_count", "ngok_count", "score", "scoring_version", "win_rate", "rating", "current_king_streak", "longest_king_streak", "current_kong_streak", "longest_kong_streak", "current_ngok_streak", "longest_ngok_streak"}
+)
+
+func (es *exportService) ExportClaims(ctx context.Context, req *dto.ExportClaimsRequest, w io.Writer) error {
+	encoder := newExportEncoder(req.Format, w, claimExportHeader, claimExportRecord)
+
+	if err := es.exportRepo.StreamClaims(ctx, nil, req.ClaimFilterRequest, encoder.Encode); err != nil {
+		return fmt.Errorf("Failed to export claims: %w", err)
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("Failed to export claims: %w", err)
+	}
+
+	logger.FromContext(ctx, es.logger).Info("claims exported", zap.String("format", req.Format), zap.Int("rows", encoder.rows))
+
+	return nil
+}
+
+func (es *exportService) ExportStats(ctx context.Context, req *dto.ExportStatsRequest, w io.Writer) error {
+	encoder := newExportEncoder(req.Format, w, playerStatExportHeader, playerStatExportRecord)
+
+	if err := es.exportRepo.StreamPlayerStats(ctx, nil, encoder.Encode); err != nil {
+		return fmt.Errorf("Failed to export player stats: %w", err)
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return fmt.Errorf("Failed to export player stats: %w", err)
+	}
+
+	logger.FromContext(ctx, es.logger).Info("player stats exported", zap.String("format", req.Format), zap.Int("rows", encoder.rows))
+
+	return nil
+}
+
+func claimExportRecord(row *dto.ClaimExportRow) []string {
+	return []string{
+		row.ID.String(),
+		row.MatchDate,
+		row.Event,
+		row.Status,
+		strconv.Itoa(row.TotalPlayer),
+		row.ClaimedPlayer,
+		row.Reporter,
+		strconv.Itoa(row.ApproveCount),
+		strconv.Itoa(row.RejectCount),
+		row.ApprovedBy,
+		row.RejectedBy,
+		strconv.FormatBool(row.IsImported),
+		row.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func playerStatExportRecord(row *dto.PlayerStatExportRow) []string {
+	return []string{
+		row.PlayerID.String(),
+		row.Username,
+		strconv.Itoa(row.TotalMatch),
+		strconv.Itoa(row.KingCount),
+		strconv.Itoa(row.KongCount),
+		strconv.Itoa(row.NgokCount),
+		strconv.Itoa(row.Score),
+		strconv.Itoa(row.ScoringVersion),
+		strconv.FormatFloat(row.WinRate, 'f', 2, 64),
+		strconv.FormatFloat(row.Rating, 'f', 2, 64),
+		strconv.Itoa(row.CurrentKingStreak),
+		strconv.Itoa(row.LongestKingStreak),
+		strconv.Itoa(row.CurrentKongStreak),
+		strconv.Itoa(row.LongestKongStreak),
+		strconv.Itoa(row.CurrentNgokStreak),
+		strconv.Itoa(row.LongestNgokStreak),
+	}
+}
+
+// exportEncoder writes rows as CSV or NDJSON. Nothing reaches the writer until
+// the first row (or the final flush), so callers can still report an error
+// cleanly when the query fails up front.
+type exportEncoder[T any] struct {
+	w      io.Writer
+	csv    *csv.Writer
+	json   *json.Encoder
+	header []string
+	record func(row *T) []string
+	rows   int
+}
+
+func newExportEncoder[T any](format string, w io.Writer, header []string, record func(row *T) []string) *exportEncoder[T] {
+	encoder := &exportEncoder[T]{
+		w:      w,
+		header: header,
+		record: record,
+	}
+
+	if format == constants.ENUM_EXPORT_FORMAT_NDJSON {
+		encoder.json = json.NewEncoder(w)
+	}
+
+	return encoder
+}
+
+func (ee *exportEncoder[T]) Encode(row *T) error {
+	ee.rows++
+	if ee.json != nil {
+		return ee.json.Encode(row)
+	}
+
+	if err := ee.writeHeader(); err != nil {
+		return err
+	}
+
+	if err := ee.csv.Write(escapeCSVFormulas(ee.record(row))); err != nil {
+		return err
+	}
+
+	if ee.rows%exportFlushEvery == 0 {
+		ee.csv.Flush()
+		return ee.csv.Error()
+	}
+
+	return nil
+}
+
+func (ee *exportEncoder[T]) Flush() error {
+	if ee.json != nil {
+		return nil
+	}
+
+	if err := ee.writeHeader(); err != nil {
+		return err
+	}
+
+	ee.csv.Flush()
+	return ee.csv.Error()
+}
+
+func (ee *exportEncoder[T]) writeHeader() error {
+	if ee.csv != nil {
+		return nil
+	}
+
+	ee.csv = csv.NewWriter(ee.w)
+	return ee.csv.Write(ee.header)
+}
+
+// escapeCSVFormulas prefixes cells a spreadsheet would run as a formula with a
+// quote, so a username like "=HYPERLINK(...)" opens as plain text. Numbers,
+// such as a negative score, are left as they are.
+func escapeCSVFormulas(record []string) []string {
+	for i, cell := range record {
+		if cell == "" || !strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell, 64); err == nil {
+			continue
+		}
+
+		record[i] = "'" + cell
+	}
+
+	return record
+}
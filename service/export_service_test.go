@@ -0,0 +1,38 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEscapeCSVFormulas(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []string
+		want   []string
+	}{
+		{
+			name:   "plain text untouched",
+			record: []string{"steve", "KING", ""},
+			want:   []string{"steve", "KING", ""},
+		},
+		{
+			name:   "formula prefixes quoted",
+			record: []string{"=HYPERLINK(\"x\")", "+cmd", "-2+3", "@SUM(A1)", "\t=1"},
+			want:   []string{"'=HYPERLINK(\"x\")", "'+cmd", "'-2+3", "'@SUM(A1)", "'\t=1"},
+		},
+		{
+			name:   "numbers kept",
+			record: []string{"-5", "+1.50", "0.25"},
+			want:   []string{"-5", "+1.50", "0.25"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeCSVFormulas(tt.record); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("escapeCSVFormulas() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/google/uuid"
+)
+
+// getUserIDFromContext reads the caller set by middleware.Authentication, so
+// the acting user always comes from the token rather than the request body.
+func getUserIDFromContext(ctx context.Context) (uuid.UUID, error) {
+	userIDString, ok := ctx.Value("user_id").(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("Failed to get user ID from context: %w", dto.ErrUnauthorized)
+	}
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("Failed parse id from string to uuid: %w: %w", dto.ErrUnauthorized, err)
+	}
+
+	return userID, nil
+}
+
+// uniqueUserIDs drops duplicates and the excluded user, which is usually the
+// actor who doesn't need to be told about their own action.
+func uniqueUserIDs(exclude uuid.UUID, userIDs ...uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{exclude: true}
+	unique := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+
+		seen[userID] = true
+		unique = append(unique, userID)
+	}
+
+	return unique
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	importRowValid    = "VALID"
+	importRowImported = "IMPORTED"
+	importRowFailed   = "FAILED"
+)
+
+type (
+	IImportService interface {
+		ImportClaims(ctx context.Context, req *dto.ImportClaimsRequest, r io.Reader) (*dto.ImportClaimsResponse, error)
+	}
+
+	importService struct {
+		db                *gorm.DB
+		claimRepo         repository.IClaimRepository
+		userRepo          repository.IUserRepository
+		claimRevisionRepo repository.IClaimRevisionRepository
+		playerStatRepo    repository.IPlayerStatRepository
+		scoringRuleRepo   repository.IScoringRuleRepository
+		ratingHistoryRepo repository.IRatingHistoryRepository
+		voteRepo          repository.IVoteRepository
+		achievementRepo   repository.IAchievementRepository
+		notificationRepo  repository.INotificationRepository
+		logger            *zap.Logger
+	}
+)
+
+func NewImportService(db *gorm.DB, claimRepo repository.IClaimRepository, userRepo repository.IUserRepository, claimRevisionRepo repository.IClaimRevisionRepository, playerStatRepo repository.IPlayerStatRepository, scoringRuleRepo repository.IScoringRuleRepository, ratingHistoryRepo repository.IRatingHistoryRepository, voteRepo repository.IVoteRepository, achievementRepo repository.IAchievementRepository, notificationRepo repository.INotificationRepository, logger *zap.Logger) *importService {
+	return &importService{
+		db:                db,
+		claimRepo:         claimRepo,
+		userRepo:          userRepo,
+		claimRevisionRepo: claimRevisionRepo,
+		playerStatRepo:    playerStatRepo,
+		scoringRuleRepo:   scoringRuleRepo,
+		ratingHistoryRepo: ratingHistoryRepo,
+		voteRepo:          voteRepo,
+		achievementRepo:   achievementRepo,
+		notificationRepo:  notificationRepo,
+		logger:            logger,
+	}
+}
+
+// importKey identifies a player's result on a match date; a file may hold it
+// only once and only if the database doesn't have it yet.
+func importKey(claim *entity.Claim) string {
+	return fmt.Sprintf("%s|%s|%s", claim.ClaimedPlayerID, claim.Event, claim.MatchDate.Format("2006-01-02"))
+}
+
+// ImportClaims reads historical results as CSV rows of date, event, player
+// username and total players. Every valid row becomes a FINAL_APPROVED claim
+// flagged as imported; invalid rows are reported and skipped. The reporter is
+// the admin running the import, or the player themselves from the CLI. The
+// claims, their revisions, stats, ratings and achievements are written in one
+// transaction, so a failed import leaves nothing behind to retry around.
+func (is *importService) ImportClaims(ctx context.Context, req *dto.ImportClaimsRequest, r io.Reader) (*dto.ImportClaimsResponse, error) {
+	reporterID, err := getUserIDFromContext(ctx)
+	if err != nil && !errors.Is(err, dto.ErrUnauthorized) {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	res := &dto.ImportClaimsResponse{
+		DryRun: req.DryRun,
+		Rows:   []dto.ImportRowResult{},
+	}
+
+	var (
+		claims  []*entity.Claim
+		results []int
+		players = map[string]*entity.User{}
+		seen    = map[string]int{}
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("Failed to read CSV: %w", err)
+			}
+
+			res.Rows = append(res.Rows, dto.ImportRowResult{Row: parseErr.Line, Status: importRowFailed, Error: parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		if line == 1 && isImportHeader(record) {
+			continue
+		}
+
+		claim, err := is.parseImportRow(ctx, record, players)
+		if err == nil {
+			if firstLine, ok := seen[importKey(claim)]; ok {
+				err = fmt.Errorf("duplicate of row %d", firstLine)
+			} else {
+				seen[importKey(claim)] = line
+			}
+		}
+		if err != nil {
+			res.Rows = append(res.Rows, dto.ImportRowResult{Row: line, Status: importRowFailed, Error: err.Error()})
+			continue
+		}
+
+		claim.ReporterID = reporterID
+		if reporterID == uuid.Nil {
+			claim.ReporterID = claim.ClaimedPlayerID
+		}
+
+		claims = append(claims, claim)
+		results = append(results, len(res.Rows))
+		res.Rows = append(res.Rows, dto.ImportRowResult{Row: line, Status: importRowValid})
+	}
+
+	claims, results, err = is.dropExistingClaims(ctx, res, claims, results, players)
+	if err != nil {
+		return nil, err
+	}
+
+	res.TotalRows = len(res.Rows)
+	res.Valid = len(claims)
+	res.Failed = res.TotalRows - res.Valid
+	if res.TotalRows == 0 {
+		return nil, fmt.Errorf("Failed import CSV has no rows: %w", dto.ErrValidationFailed)
+	}
+
+	log := logger.FromContext(ctx, is.logger)
+	if req.DryRun || len(claims) == 0 {
+		log.Info("claims import checked", zap.Bool("dry_run", req.DryRun), zap.Int("valid", res.Valid), zap.Int("failed", res.Failed))
+		return res, nil
+	}
+
+	err = is.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := is.claimRepo.CreateBatch(ctx, tx, claims); err != nil {
+			return fmt.Errorf("Failed to import claims: %w", err)
+		}
+
+		revisions := make([]*entity.ClaimRevision, 0, len(claims))
+		for _, claim := range claims {
+			revision, err := newClaimRevision(claim.ID, claim.ReporterID, entity.RevisionCreate, diffClaim(nil, claim), "imported from CSV")
+			if err != nil {
+				return err
+			}
+			revisions = append(revisions, revision)
+		}
+		if err := is.claimRevisionRepo.CreateMany(ctx, tx, revisions); err != nil {
+			return fmt.Errorf("Failed to create claim revisions: %w", err)
+		}
+
+		rule, err := currentScoringRule(ctx, tx, is.scoringRuleRepo)
+		if err != nil {
+			return err
+		}
+		if _, _, err := is.playerStatRepo.RecomputeAll(ctx, tx, rule); err != nil {
+			return fmt.Errorf("Failed to recompute player stats: %w", err)
+		}
+		if _, err := recomputeRatings(ctx, tx, is.claimRepo, is.ratingHistoryRepo); err != nil {
+			return err
+		}
+
+		return is.awardImportAchievements(ctx, tx, claims)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, claim := range claims {
+		claimID := claim.ID
+		res.Rows[results[i]].Status = importRowImported
+		res.Rows[results[i]].ClaimID = &claimID
+	}
+	res.Imported = len(claims)
+	log.Info("claims imported", zap.Int("imported", res.Imported), zap.Int("failed", res.Failed))
+
+	return res, nil
+}
+
+// dropExistingClaims fails the rows whose result is already recorded, looking
+// up every claim in the file's date range with a single query.
+func (is *importService) dropExistingClaims(ctx context.Context, res *dto.ImportClaimsResponse, claims []*entity.Claim, results []int, players map[string]*entity.User) ([]*entity.Claim, []int, error) {
+	if len(claims) == 0 {
+		return claims, results, nil
+	}
+
+	from, to := claims[0].MatchDate, claims[0].MatchDate
+	for _, claim := range claims {
+		if claim.MatchDate.Before(from) {
+			from = claim.MatchDate
+		}
+		if claim.MatchDate.After(to) {
+			to = claim.MatchDate
+		}
+	}
+
+	existing, err := is.claimRepo.GetAllByMatchDateRange(ctx, nil, from, to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to check existing claims: %w", err)
+	}
+	existingKeys := make(map[string]bool, len(existing))
+	for _, claim := range existing {
+		existingKeys[importKey(claim)] = true
+	}
+
+	usernames := make(map[uuid.UUID]string, len(players))
+	for username, player := range players {
+		if player != nil {
+			usernames[player.ID] = username
+		}
+	}
+
+	keptClaims := make([]*entity.Claim, 0, len(claims))
+	keptResults := make([]int, 0, len(results))
+	for i, claim := range claims {
+		if !existingKeys[importKey(claim)] {
+			keptClaims = append(keptClaims, claim)
+			keptResults = append(keptResults, results[i])
+			continue
+		}
+
+		res.Rows[results[i]].Status = importRowFailed
+		res.Rows[results[i]].Error = fmt.Sprintf("%s already has a %s claim on %s", usernames[claim.ClaimedPlayerID], claim.Event, claim.MatchDate.Format("2006-01-02"))
+	}
+
+	return keptClaims, keptResults, nil
+}
+
+// awardImportAchievements evaluates badges once per imported player, crediting
+// them to that player's latest imported claim.
+func (is *importService) awardImportAchievements(ctx context.Context, tx *gorm.DB, claims []*entity.Claim) error {
+	latest := map[uuid.UUID]*entity.Claim{}
+	playerIDs := make([]uuid.UUID, 0)
+	for _, claim := range claims {
+		current, ok := latest[claim.ClaimedPlayerID]
+		if !ok {
+			playerIDs = append(playerIDs, claim.ClaimedPlayerID)
+		}
+		if !ok || claim.MatchDate.After(current.MatchDate) {
+			latest[claim.ClaimedPlayerID] = claim
+		}
+	}
+
+	evaluator := &achievementEvaluator{
+		claimRepo:        is.claimRepo,
+		voteRepo:         is.voteRepo,
+		achievementRepo:  is.achievementRepo,
+		notificationRepo: is.notificationRepo,
+		logger:           is.logger,
+	}
+	for _, playerID := range playerIDs {
+		if err := evaluator.award(ctx, tx, latest[playerID], []uuid.UUID{playerID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseImportRow validates one CSV record and builds the claim it describes.
+// Players are cached by username since the same names repeat on every row.
+func (is *importService) parseImportRow(ctx context.Context, record []string, players map[string]*entity.User) (*entity.Claim, error) {
+	if len(record) != 4 {
+		return nil, fmt.Errorf("expected 4 columns (date, event, username, total_players), got %d", len(record))
+	}
+
+	matchDate, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", record[0])
+	}
+
+	event := entity.ClaimEvent(strings.ToUpper(strings.TrimSpace(record[1])))
+	if event != entity.EventKing && event != entity.EventKong && event != entity.EventNgok {
+		return nil, fmt.Errorf("invalid event %q, expected KING, KONG or NGOK", record[1])
+	}
+
+	username := strings.TrimSpace(record[2])
+	player, ok := players[username]
+	if !ok {
+		user, found, err := is.userRepo.GetByUsername(ctx, nil, &username)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get player %q: %w", username, err)
+		}
+		if found {
+			player = user
+		}
+		players[username] = player
+	}
+	if player == nil {
+		return nil, fmt.Errorf("player %q not found", username)
+	}
+
+	totalPlayer, err := strconv.Atoi(strings.TrimSpace(record[3]))
+	if err != nil || totalPlayer < 2 || totalPlayer > 8 {
+		return nil, fmt.Errorf("invalid total players %q, expected a number from 2 to 8", record[3])
+	}
+
+	return &entity.Claim{
+		ID:              uuid.New(),
+		Event:           event,
+		Status:          entity.StatusFinalApproved,
+		MatchDate:       matchDate,
+		TotalPlayer:     totalPlayer,
+		ClaimedPlayerID: player.ID,
+		IsImported:      true,
+	}, nil
+}
+
+func isImportHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+
+	first := strings.ToLower(strings.TrimSpace(record[0]))
+	return first == "date" || first == "match_date"
+}
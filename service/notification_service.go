@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type (
+	INotificationService interface {
+		GetAll(ctx context.Context) ([]dto.NotificationResponse, error)
+		MarkAsRead(ctx context.Context, id *uuid.UUID) (*dto.NotificationResponse, error)
+	}
+
+	notificationService struct {
+		notificationRepo repository.INotificationRepository
+		jwt              jwt.IJWT
+		logger           *zap.Logger
+	}
+)
+
+func NewNotificationService(notificationRepo repository.INotificationRepository, jwt jwt.IJWT, logger *zap.Logger) *notificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		jwt:              jwt,
+		logger:           logger,
+	}
+}
+
+func (ns *notificationService) GetAll(ctx context.Context) ([]dto.NotificationResponse, error) {
+	token := ctx.Value("Authorization").(string)
+	userIDString, err := ns.jwt.GetUserIDByToken(token)
+	if err != nil {
+		return []dto.NotificationResponse{}, fmt.Errorf("Failed to get user ID by token: %w", err)
+	}
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return []dto.NotificationResponse{}, fmt.Errorf("Failed parse id from string to uuid: %w", err)
+	}
+
+	datas, err := ns.notificationRepo.GetAllByUserID(ctx, nil, &userID)
+	if err != nil {
+		return []dto.NotificationResponse{}, fmt.Errorf("Failed to get all notifications: %w", err)
+	}
+
+	notifications := make([]dto.NotificationResponse, 0, len(datas))
+	for _, notification := range datas {
+		notifications = append(notifications, dto.NotificationResponse{
+			ID:        notification.ID,
+			Type:      notification.Type,
+			Message:   notification.Message,
+			IsRead:    notification.IsRead,
+			ClaimID:   notification.ClaimID,
+			CreatedAt: notification.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return notifications, nil
+}
+
+func (ns *notificationService) MarkAsRead(ctx context.Context, id *uuid.UUID) (*dto.NotificationResponse, error) {
+	token := ctx.Value("Authorization").(string)
+	userIDString, err := ns.jwt.GetUserIDByToken(token)
+	if err != nil {
+		return &dto.NotificationResponse{}, fmt.Errorf("Failed to get user ID by token: %w", err)
+	}
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return &dto.NotificationResponse{}, fmt.Errorf("Failed parse id from string to uuid: %w", err)
+	}
+
+	notification, found, err := ns.notificationRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return &dto.NotificationResponse{}, fmt.Errorf("Failed to get notification by id: %w", err)
+	}
+	if !found || notification.UserID != userID {
+		return &dto.NotificationResponse{}, fmt.Errorf("Failed notification not found: %w", dto.ErrNotFound)
+	}
+
+	notification.IsRead = true
+
+	if err := ns.notificationRepo.Update(ctx, nil, notification); err != nil {
+		return &dto.NotificationResponse{}, fmt.Errorf("Failed to update notification: %w", err)
+	}
+
+	logger.FromContext(ctx, ns.logger).Debug("notification read", zap.String("notification_id", notification.ID.String()))
+
+	res := &dto.NotificationResponse{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Message:   notification.Message,
+		IsRead:    notification.IsRead,
+		ClaimID:   notification.ClaimID,
+		CreatedAt: notification.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+
+	return res, nil
+}
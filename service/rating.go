@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ratingKFactor caps how far a single match day can move a rating.
+const ratingKFactor = 32.0
+
+// eventRank orders results within a match: finishing KING beats KONG, which
+// beats NGOK.
+var eventRank = map[entity.ClaimEvent]float64{
+	entity.EventKing: 2,
+	entity.EventKong: 1,
+	entity.EventNgok: 0,
+}
+
+// computeRatings replays approved claims as a multiplayer Elo. Claims with the
+// same entity.Claim.MatchKey form one match between their claimed players;
+// each player is scored against every other participant pairwise, and the
+// K-factor is split across opponents so bigger tables don't swing ratings
+// harder. A player with several claims in one match is ranked by their
+// average result.
+func computeRatings(claims []*entity.Claim) ([]*entity.RatingHistory, map[uuid.UUID]float64) {
+	type match struct {
+		date    time.Time
+		results map[uuid.UUID][]float64
+	}
+
+	var matches []*match
+	byKey := map[string]*match{}
+	for _, claim := range claims {
+		m, ok := byKey[claim.MatchKey()]
+		if !ok {
+			m = &match{date: claim.MatchDate, results: map[uuid.UUID][]float64{}}
+			byKey[claim.MatchKey()] = m
+			matches = append(matches, m)
+		}
+
+		m.results[claim.ClaimedPlayerID] = append(m.results[claim.ClaimedPlayerID], eventRank[claim.Event])
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].date.Before(matches[j].date)
+	})
+
+	ratings := map[uuid.UUID]float64{}
+	histories := make([]*entity.RatingHistory, 0)
+	for _, m := range matches {
+		// A lone participant has nobody to be compared against.
+		if len(m.results) < 2 {
+			continue
+		}
+
+		players := make([]uuid.UUID, 0, len(m.results))
+		ranks := make(map[uuid.UUID]float64, len(m.results))
+		for playerID, results := range m.results {
+			players = append(players, playerID)
+
+			total := 0.0
+			for _, result := range results {
+				total += result
+			}
+			ranks[playerID] = total / float64(len(results))
+
+			if _, ok := ratings[playerID]; !ok {
+				ratings[playerID] = entity.InitialRating
+			}
+		}
+		sort.Slice(players, func(i, j int) bool {
+			return players[i].String() < players[j].String()
+		})
+
+		opponents := len(players) - 1
+		deltas := make(map[uuid.UUID]float64, len(players))
+		for _, player := range players {
+			for _, opponent := range players {
+				if player == opponent {
+					continue
+				}
+
+				actual := 0.5
+				if ranks[player] > ranks[opponent] {
+					actual = 1
+				} else if ranks[player] < ranks[opponent] {
+					actual = 0
+				}
+				expected := 1 / (1 + math.Pow(10, (ratings[opponent]-ratings[player])/400))
+
+				deltas[player] += ratingKFactor / float64(opponents) * (actual - expected)
+			}
+		}
+
+		for _, player := range players {
+			before := ratings[player]
+			after := math.Round((before+deltas[player])*100) / 100
+			ratings[player] = after
+
+			histories = append(histories, &entity.RatingHistory{
+				MatchDate:    m.date,
+				RatingBefore: before,
+				RatingAfter:  after,
+				Delta:        math.Round((after-before)*100) / 100,
+				Opponents:    opponents,
+				PlayerID:     player,
+			})
+		}
+	}
+
+	return histories, ratings
+}
+
+// recomputeRatings rebuilds every rating from scratch. An edit to an old
+// claim changes every later match, so there is no cheaper incremental path.
+// It returns the replayed ratings; players missing from the map are back at
+// entity.InitialRating.
+func recomputeRatings(ctx context.Context, tx *gorm.DB, claimRepo repository.IClaimRepository, ratingHistoryRepo repository.IRatingHistoryRepository) (map[uuid.UUID]float64, error) {
+	claims, err := claimRepo.GetAllFinalApproved(ctx, tx)
+	if err != nil {
+		return map[uuid.UUID]float64{}, fmt.Errorf("Failed to get approved claims: %w", err)
+	}
+
+	histories, ratings := computeRatings(claims)
+	if err := ratingHistoryRepo.ReplaceAll(ctx, tx, histories, ratings); err != nil {
+		return map[uuid.UUID]float64{}, fmt.Errorf("Failed to replace rating history: %w", err)
+	}
+
+	return ratings, nil
+}
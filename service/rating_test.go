@@ -0,0 +1,152 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/google/uuid"
+)
+
+func TestComputeRatings(t *testing.T) {
+	var (
+		playerA = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+		playerB = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+		playerC = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+
+		day1 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		day2 = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	)
+
+	claim := func(playerID uuid.UUID, event entity.ClaimEvent, matchDate time.Time, screenshotURL string) *entity.Claim {
+		return &entity.Claim{
+			ClaimedPlayerID: playerID,
+			Event:           event,
+			MatchDate:       matchDate,
+			ScreenshotURL:   screenshotURL,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		claims    []*entity.Claim
+		histories int
+		ratings   map[uuid.UUID]float64
+	}{
+		{
+			name:      "no claims",
+			claims:    nil,
+			histories: 0,
+			ratings:   map[uuid.UUID]float64{},
+		},
+		{
+			name: "lone participant is skipped",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+			},
+			histories: 0,
+			ratings:   map[uuid.UUID]float64{},
+		},
+		{
+			name: "same day with different screenshots is two matches",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+				claim(playerB, entity.EventNgok, day1, "match-2.png"),
+			},
+			histories: 0,
+			ratings:   map[uuid.UUID]float64{},
+		},
+		{
+			name: "winner takes half the K-factor from an equal opponent",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+				claim(playerB, entity.EventNgok, day1, "match-1.png"),
+			},
+			histories: 2,
+			ratings: map[uuid.UUID]float64{
+				playerA: 1516,
+				playerB: 1484,
+			},
+		},
+		{
+			name: "equal results leave ratings unchanged",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKong, day1, "match-1.png"),
+				claim(playerB, entity.EventKong, day1, "match-1.png"),
+			},
+			histories: 2,
+			ratings: map[uuid.UUID]float64{
+				playerA: 1500,
+				playerB: 1500,
+			},
+		},
+		{
+			name: "several claims in one match are averaged",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+				claim(playerA, entity.EventNgok, day1, "match-1.png"),
+				claim(playerB, entity.EventKong, day1, "match-1.png"),
+			},
+			histories: 2,
+			ratings: map[uuid.UUID]float64{
+				playerA: 1500,
+				playerB: 1500,
+			},
+		},
+		{
+			name: "K-factor is split across three players",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+				claim(playerB, entity.EventKong, day1, "match-1.png"),
+				claim(playerC, entity.EventNgok, day1, "match-1.png"),
+			},
+			histories: 3,
+			ratings: map[uuid.UUID]float64{
+				playerA: 1516,
+				playerB: 1500,
+				playerC: 1484,
+			},
+		},
+		{
+			name: "later matches start from earlier ratings",
+			claims: []*entity.Claim{
+				claim(playerA, entity.EventKing, day2, "match-2.png"),
+				claim(playerB, entity.EventNgok, day2, "match-2.png"),
+				claim(playerA, entity.EventKing, day1, "match-1.png"),
+				claim(playerB, entity.EventNgok, day1, "match-1.png"),
+			},
+			histories: 4,
+			ratings: map[uuid.UUID]float64{
+				playerA: 1530.53,
+				playerB: 1469.47,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			histories, ratings := computeRatings(tt.claims)
+
+			if len(histories) != tt.histories {
+				t.Fatalf("got %d histories, want %d", len(histories), tt.histories)
+			}
+			if len(ratings) != len(tt.ratings) {
+				t.Fatalf("got ratings %v, want %v", ratings, tt.ratings)
+			}
+			for playerID, want := range tt.ratings {
+				if got := ratings[playerID]; got != want {
+					t.Errorf("rating of %s = %v, want %v", playerID, got, want)
+				}
+			}
+			for i, history := range histories {
+				if want := math.Round((history.RatingAfter-history.RatingBefore)*100) / 100; history.Delta != want {
+					t.Errorf("history %d delta = %v, want %v", i, history.Delta, want)
+				}
+				if i > 0 && history.MatchDate.Before(histories[i-1].MatchDate) {
+					t.Errorf("history %d is out of match order", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/helper"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type (
+	IReactionService interface {
+		Add(ctx context.Context, req *dto.ReactionRequest) ([]dto.ReactionCountResponse, error)
+		Remove(ctx context.Context, req *dto.ReactionRequest) ([]dto.ReactionCountResponse, error)
+	}
+
+	reactionService struct {
+		reactionRepo repository.IReactionRepository
+		claimRepo    repository.IClaimRepository
+		logger       *zap.Logger
+	}
+)
+
+func NewReactionService(reactionRepo repository.IReactionRepository, claimRepo repository.IClaimRepository, logger *zap.Logger) *reactionService {
+	return &reactionService{
+		reactionRepo: reactionRepo,
+		claimRepo:    claimRepo,
+		logger:       logger,
+	}
+}
+
+func (rs *reactionService) Add(ctx context.Context, req *dto.ReactionRequest) ([]dto.ReactionCountResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return []dto.ReactionCountResponse{}, err
+	}
+
+	if !helper.IsValidEmoji(req.Emoji) {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed reaction must be an emoji: %w", dto.ErrValidationFailed)
+	}
+
+	_, found, err := rs.claimRepo.GetDetailByID(ctx, nil, &req.ClaimID)
+	if err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to get claim by id: %w", err)
+	}
+	if !found {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed claim not found: %w", dto.ErrNotFound)
+	}
+
+	_, found, err = rs.reactionRepo.GetByClaimIDUserIDAndEmoji(ctx, nil, &req.ClaimID, &userID, req.Emoji)
+	if err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to get reaction: %w", err)
+	}
+	if found {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed already reacted with %s: %w", req.Emoji, dto.ErrAlreadyExists)
+	}
+
+	reaction := &entity.Reaction{
+		Emoji:   req.Emoji,
+		ClaimID: req.ClaimID,
+		UserID:  userID,
+	}
+	if err := rs.reactionRepo.Create(ctx, nil, reaction); err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to create reaction: %w", err)
+	}
+
+	logger.FromContext(ctx, rs.logger).Debug("reaction added", zap.String("claim_id", req.ClaimID.String()), zap.String("emoji", req.Emoji))
+
+	return rs.countByClaimID(ctx, req.ClaimID)
+}
+
+func (rs *reactionService) Remove(ctx context.Context, req *dto.ReactionRequest) ([]dto.ReactionCountResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return []dto.ReactionCountResponse{}, err
+	}
+
+	reaction, found, err := rs.reactionRepo.GetByClaimIDUserIDAndEmoji(ctx, nil, &req.ClaimID, &userID, req.Emoji)
+	if err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to get reaction: %w", err)
+	}
+	if !found {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed reaction not found: %w", dto.ErrNotFound)
+	}
+
+	if err := rs.reactionRepo.DeleteByID(ctx, nil, &reaction.ID); err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to delete reaction: %w", err)
+	}
+
+	logger.FromContext(ctx, rs.logger).Debug("reaction removed", zap.String("claim_id", req.ClaimID.String()), zap.String("emoji", req.Emoji))
+
+	return rs.countByClaimID(ctx, req.ClaimID)
+}
+
+func (rs *reactionService) countByClaimID(ctx context.Context, claimID uuid.UUID) ([]dto.ReactionCountResponse, error) {
+	counts, err := rs.reactionRepo.CountByClaimIDs(ctx, nil, []uuid.UUID{claimID})
+	if err != nil {
+		return []dto.ReactionCountResponse{}, fmt.Errorf("Failed to count reactions: %w", err)
+	}
+
+	if counts[claimID] == nil {
+		return []dto.ReactionCountResponse{}, nil
+	}
+
+	return counts[claimID], nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reputation is the voter's agreement rate with a Bayesian prior of
+// reputationPriorVotes decided votes at 50%, so a handful of votes can't push
+// a newcomer to either extreme.
+const reputationPriorVotes = 4.0
+
+// voteWeightingEnv opts claim resolution into reputation-weighted votes.
+const voteWeightingEnv = "VOTE_WEIGHTING"
+
+func voterReputation(stat *dto.VoterStatRepositoryResponse) float64 {
+	return (float64(stat.Agreed) + reputationPriorVotes/2) / (float64(stat.Decided) + reputationPriorVotes)
+}
+
+// voteWeight maps reputation onto 0.5..1.5, leaving a neutral voter at 1 so
+// an unweighted and a weighted tally agree until records diverge.
+func voteWeight(reputation float64) float64 {
+	return 0.5 + reputation
+}
+
+func isVoteWeightingEnabled() bool {
+	return os.Getenv(voteWeightingEnv) == "reputation"
+}
+
+func toVoterStatResponse(stat *dto.VoterStatRepositoryResponse) *dto.VoterStatResponse {
+	reputation := voterReputation(stat)
+	res := &dto.VoterStatResponse{
+		Voter: dto.UserSimpleResponse{
+			ID:        stat.VoterID,
+			Username:  stat.Username,
+			AvatarURL: stat.AvatarURL,
+		},
+		VotesCast:      stat.VotesCast,
+		Decided:        stat.Decided,
+		Agreed:         stat.Agreed,
+		AvgVoteSeconds: math.Round(stat.AvgVoteSeconds),
+		Reputation:     math.Round(reputation*1000) / 1000,
+		VoteWeight:     math.Round(voteWeight(reputation)*1000) / 1000,
+	}
+	if stat.Decided > 0 {
+		res.AgreementRate = float64(stat.Agreed) / float64(stat.Decided)
+	}
+	if stat.VotesCast > 0 {
+		res.RejectRate = float64(stat.RejectCount) / float64(stat.VotesCast)
+	}
+	if stat.EligibleClaims > 0 {
+		res.ParticipationRate = math.Min(float64(stat.VotesCast)/float64(stat.EligibleClaims), 1)
+	}
+
+	return res
+}
+
+// voteWeights returns every user's current weight, keyed by user. It reads
+// through tx so a vote is weighed against the records its transaction sees.
+func voteWeights(ctx context.Context, tx *gorm.DB, voterStatRepo repository.IVoterStatRepository) (map[uuid.UUID]float64, error) {
+	stats, err := voterStatRepo.GetAll(ctx, tx)
+	if err != nil {
+		return map[uuid.UUID]float64{}, fmt.Errorf("Failed to get voter stats: %w", err)
+	}
+
+	weights := make(map[uuid.UUID]float64, len(stats))
+	for _, stat := range stats {
+		weights[stat.VoterID] = voteWeight(voterReputation(stat))
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	IScoringRuleService interface {
+		GetCurrent(ctx context.Context) (*dto.ScoringRuleResponse, error)
+		GetAll(ctx context.Context) ([]*dto.ScoringRuleResponse, error)
+		Update(ctx context.Context, req *dto.UpdateScoringRuleRequest) (*dto.ScoringRuleResponse, error)
+	}
+
+	scoringRuleService struct {
+		db              *gorm.DB
+		scoringRuleRepo repository.IScoringRuleRepository
+		playerStatRepo  repository.IPlayerStatRepository
+		logger          *zap.Logger
+	}
+)
+
+func NewScoringRuleService(db *gorm.DB, scoringRuleRepo repository.IScoringRuleRepository, playerStatRepo repository.IPlayerStatRepository, logger *zap.Logger) *scoringRuleService {
+	return &scoringRuleService{
+		db:              db,
+		scoringRuleRepo: scoringRuleRepo,
+		playerStatRepo:  playerStatRepo,
+		logger:          logger,
+	}
+}
+
+// currentScoringRule returns the latest saved version, falling back to the
+// built-in defaults before any admin has configured one.
+func currentScoringRule(ctx context.Context, tx *gorm.DB, scoringRuleRepo repository.IScoringRuleRepository) (*entity.ScoringRule, error) {
+	rule, found, err := scoringRuleRepo.GetLatest(ctx, tx)
+	if err != nil {
+		return &entity.ScoringRule{}, fmt.Errorf("Failed to get scoring rule: %w", err)
+	}
+	if !found {
+		return entity.DefaultScoringRule(), nil
+	}
+
+	return rule, nil
+}
+
+func toScoringRuleResponse(rule *entity.ScoringRule) *dto.ScoringRuleResponse {
+	res := &dto.ScoringRuleResponse{
+		ID:                 rule.ID,
+		Version:            rule.Version,
+		KingPoints:         rule.KingPoints,
+		KongPoints:         rule.KongPoints,
+		NgokPoints:         rule.NgokPoints,
+		ScaleByTotalPlayer: rule.ScaleByTotalPlayer,
+		BaselinePlayers:    rule.BaselinePlayers,
+		TimestampTemplate: dto.TimestampTemplate{
+			CreatedAt: rule.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: rule.UpdatedAt.Format("2006-01-02 15:04:05"),
+		},
+	}
+	if rule.CreatedBy != nil {
+		res.CreatedBy = &dto.UserSimpleResponse{
+			ID:        rule.CreatedBy.ID,
+			Username:  rule.CreatedBy.Username,
+			AvatarURL: rule.CreatedBy.AvatarURL,
+		}
+	}
+
+	return res
+}
+
+func (srs *scoringRuleService) GetCurrent(ctx context.Context) (*dto.ScoringRuleResponse, error) {
+	rule, err := currentScoringRule(ctx, nil, srs.scoringRuleRepo)
+	if err != nil {
+		return &dto.ScoringRuleResponse{}, err
+	}
+
+	return toScoringRuleResponse(rule), nil
+}
+
+func (srs *scoringRuleService) GetAll(ctx context.Context) ([]*dto.ScoringRuleResponse, error) {
+	rules, err := srs.scoringRuleRepo.GetAll(ctx, nil)
+	if err != nil {
+		return []*dto.ScoringRuleResponse{}, fmt.Errorf("Failed to get all scoring rules: %w", err)
+	}
+
+	res := make([]*dto.ScoringRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, toScoringRuleResponse(rule))
+	}
+
+	return res, nil
+}
+
+// Update saves the request as the next rule version and recomputes every
+// player's stats with it, so scores never mix two versions.
+func (srs *scoringRuleService) Update(ctx context.Context, req *dto.UpdateScoringRuleRequest) (*dto.ScoringRuleResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ScoringRuleResponse{}, err
+	}
+
+	var (
+		rule  *entity.ScoringRule
+		stats []*entity.PlayerStat
+	)
+	err = srs.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := currentScoringRule(ctx, tx, srs.scoringRuleRepo)
+		if err != nil {
+			return err
+		}
+
+		created := &entity.ScoringRule{
+			Version:            current.Version + 1,
+			KingPoints:         req.KingPoints,
+			KongPoints:         req.KongPoints,
+			NgokPoints:         req.NgokPoints,
+			ScaleByTotalPlayer: req.ScaleByTotalPlayer,
+			BaselinePlayers:    req.BaselinePlayers,
+			CreatedByID:        &userID,
+		}
+		if err := srs.scoringRuleRepo.Create(ctx, tx, created); err != nil {
+			return fmt.Errorf("Failed to create scoring rule: %w", err)
+		}
+
+		if _, stats, err = srs.playerStatRepo.RecomputeAll(ctx, tx, created); err != nil {
+			return fmt.Errorf("Failed to recompute player stats: %w", err)
+		}
+
+		latest, found, err := srs.scoringRuleRepo.GetLatest(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("Failed to get scoring rule: %w", err)
+		}
+		if !found {
+			return fmt.Errorf("Failed to get scoring rule: %w", dto.ErrNotFound)
+		}
+		rule = latest
+
+		return nil
+	})
+	if err != nil {
+		return &dto.ScoringRuleResponse{}, err
+	}
+
+	res := toScoringRuleResponse(rule)
+	res.RecomputedPlayers = len(stats)
+
+	logger.FromContext(ctx, srs.logger).Info("scoring rule updated",
+		zap.Int("version", rule.Version),
+		zap.Int("recomputed_players", res.RecomputedPlayers),
+	)
+
+	return res, nil
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
+	"github.com/Amierza/mc-kalak-backend/logger"
+	"github.com/Amierza/mc-kalak-backend/repository"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type (
+	IStatService interface {
+		RecomputeAll(ctx context.Context, dryRun bool) (*dto.RecomputeStatsResponse, error)
+		GetLeaderboard(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error)
+		GetHeadToHead(ctx context.Context, req *dto.HeadToHeadRequest) (*dto.HeadToHeadResponse, error)
+		GetVoterStats(ctx context.Context) ([]*dto.VoterStatResponse, error)
+	}
+
+	statService struct {
+		db                *gorm.DB
+		playerStatRepo    repository.IPlayerStatRepository
+		scoringRuleRepo   repository.IScoringRuleRepository
+		claimRepo         repository.IClaimRepository
+		ratingHistoryRepo repository.IRatingHistoryRepository
+		userRepo          repository.IUserRepository
+		voterStatRepo     repository.IVoterStatRepository
+		logger            *zap.Logger
+	}
+)
+
+func NewStatService(db *gorm.DB, playerStatRepo repository.IPlayerStatRepository, scoringRuleRepo repository.IScoringRuleRepository, claimRepo repository.IClaimRepository, ratingHistoryRepo repository.IRatingHistoryRepository, userRepo repository.IUserRepository, voterStatRepo repository.IVoterStatRepository, logger *zap.Logger) *statService {
+	return &statService{
+		db:                db,
+		playerStatRepo:    playerStatRepo,
+		scoringRuleRepo:   scoringRuleRepo,
+		claimRepo:         claimRepo,
+		ratingHistoryRepo: ratingHistoryRepo,
+		userRepo:          userRepo,
+		voterStatRepo:     voterStatRepo,
+		logger:            logger,
+	}
+}
+
+// leaderboardColumns whitelists the sortable PlayerStat columns.
+var leaderboardColumns = map[string]string{
+	"score":               "score",
+	"rating":              "rating",
+	"win_rate":            "win_rate",
+	"total_match":         "total_match",
+	"king_count":          "king_count",
+	"kong_count":          "kong_count",
+	"ngok_count":          "ngok_count",
+	"current_king_streak": "current_king_streak",
+	"longest_king_streak": "longest_king_streak",
+	"current_kong_streak": "current_kong_streak",
+	"longest_kong_streak": "longest_kong_streak",
+	"current_ngok_streak": "current_ngok_streak",
+	"longest_ngok_streak": "longest_ngok_streak",
+}
+
+const (
+	defaultLeaderboardLimit = 10
+	headToHeadRecentLimit   = 10
+)
+
+func toPlayerStatResponse(stat *entity.PlayerStat) dto.PlayerStatResponse {
+	return dto.PlayerStatResponse{
+		Player: dto.UserSimpleResponse{
+			ID:        stat.Player.ID,
+			Username:  stat.Player.Username,
+			AvatarURL: stat.Player.AvatarURL,
+		},
+		TotalMatch: stat.TotalMatch,
+		KingCount:  stat.KingCount,
+		KongCount:  stat.KongCount,
+		NgokCount:  stat.NgokCount,
+		Score:      stat.Score,
+		WinRate:    stat.WinRate,
+		Rating:     stat.Rating,
+		Streaks: dto.PlayerStreaksResponse{
+			King: dto.StreakResponse{Current: stat.CurrentKingStreak, Longest: stat.LongestKingStreak},
+			Kong: dto.StreakResponse{Current: stat.CurrentKongStreak, Longest: stat.LongestKongStreak},
+			Ngok: dto.StreakResponse{Current: stat.CurrentNgokStreak, Longest: stat.LongestNgokStreak},
+		},
+	}
+}
+
+func playerStatFieldValues(stat *entity.PlayerStat) map[string]string {
+	if stat == nil {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		"total_match":     strconv.Itoa(stat.TotalMatch),
+		"king_count":      strconv.Itoa(stat.KingCount),
+		"kong_count":      strconv.Itoa(stat.KongCount),
+		"ngok_count":      strconv.Itoa(stat.NgokCount),
+		"score":           strconv.Itoa(stat.Score),
+		"scoring_version": strconv.Itoa(stat.ScoringVersion),
+		"win_rate":        strconv.FormatFloat(stat.WinRate, 'f', 4, 64),
+		"rating":          strconv.FormatFloat(stat.Rating, 'f', 2, 64),
+
+		"current_king_streak": strconv.Itoa(stat.CurrentKingStreak),
+		"longest_king_streak": strconv.Itoa(stat.LongestKingStreak),
+		"current_kong_streak": strconv.Itoa(stat.CurrentKongStreak),
+		"longest_kong_streak": strconv.Itoa(stat.LongestKongStreak),
+		"current_ngok_streak": strconv.Itoa(stat.CurrentNgokStreak),
+		"longest_ngok_streak": strconv.Itoa(stat.LongestNgokStreak),
+	}
+}
+
+// diffPlayerStat lists the fields that differ; a nil side means the row is
+// being created or dropped.
+func diffPlayerStat(before, after *entity.PlayerStat) []dto.StatFieldChange {
+	fields := []string{
+		"total_match", "king_count", "kong_count", "ngok_count", "score", "scoring_version", "win_rate", "rating",
+		"current_king_streak", "longest_king_streak", "current_kong_streak", "longest_kong_streak", "current_ngok_streak", "longest_ngok_streak",
+	}
+	oldValues := playerStatFieldValues(before)
+	newValues := playerStatFieldValues(after)
+
+	changes := make([]dto.StatFieldChange, 0, len(fields))
+	for _, field := range fields {
+		if oldValues[field] == newValues[field] {
+			continue
+		}
+
+		changes = append(changes, dto.StatFieldChange{
+			Field: field,
+			Old:   oldValues[field],
+			New:   newValues[field],
+		})
+	}
+
+	return changes
+}
+
+// errDryRun rolls back a recompute whose results are only reported.
+var errDryRun = errors.New("dry run")
+
+// RecomputeAll rebuilds every PlayerStat row and replays ratings from
+// finalized claims under the current scoring rule, in one transaction, and
+// reports what changed per player. A dry run rolls the transaction back.
+func (ss *statService) RecomputeAll(ctx context.Context, dryRun bool) (*dto.RecomputeStatsResponse, error) {
+	var (
+		rule          *entity.ScoringRule
+		before, after []*entity.PlayerStat
+	)
+	err := ss.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		rule, err = currentScoringRule(ctx, tx, ss.scoringRuleRepo)
+		if err != nil {
+			return err
+		}
+
+		before, after, err = ss.playerStatRepo.RecomputeAll(ctx, tx, rule)
+		if err != nil {
+			return fmt.Errorf("Failed to recompute player stats: %w", err)
+		}
+
+		ratings, err := recomputeRatings(ctx, tx, ss.claimRepo, ss.ratingHistoryRepo)
+		if err != nil {
+			return err
+		}
+		for _, stat := range after {
+			stat.Rating = entity.InitialRating
+			if rating, ok := ratings[stat.PlayerID]; ok {
+				stat.Rating = rating
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return &dto.RecomputeStatsResponse{}, err
+	}
+
+	res := buildRecomputeStatsResponse(rule, before, after, dryRun)
+	logger.FromContext(ctx, ss.logger).Info("player stats recomputed",
+		zap.Bool("dry_run", dryRun),
+		zap.Int("scoring_version", res.ScoringVersion),
+		zap.Int("changed_players", res.ChangedPlayers),
+		zap.Int("total_players", res.TotalPlayers),
+	)
+
+	return res, nil
+}
+
+func buildRecomputeStatsResponse(rule *entity.ScoringRule, before, after []*entity.PlayerStat, dryRun bool) *dto.RecomputeStatsResponse {
+	beforeByPlayer := make(map[uuid.UUID]*entity.PlayerStat, len(before))
+	for _, stat := range before {
+		beforeByPlayer[stat.PlayerID] = stat
+	}
+
+	diffs := make([]*dto.PlayerStatDiffResponse, 0)
+	for _, stat := range after {
+		changes := diffPlayerStat(beforeByPlayer[stat.PlayerID], stat)
+		delete(beforeByPlayer, stat.PlayerID)
+		if len(changes) == 0 {
+			continue
+		}
+
+		diffs = append(diffs, &dto.PlayerStatDiffResponse{
+			Player: dto.UserSimpleResponse{
+				ID:        stat.Player.ID,
+				Username:  stat.Player.Username,
+				AvatarURL: stat.Player.AvatarURL,
+			},
+			Changes: changes,
+		})
+	}
+
+	// Whatever is left belonged to players who no longer exist.
+	for _, stat := range before {
+		if _, dropped := beforeByPlayer[stat.PlayerID]; !dropped {
+			continue
+		}
+
+		diffs = append(diffs, &dto.PlayerStatDiffResponse{
+			Player: dto.UserSimpleResponse{
+				ID:        stat.PlayerID,
+				Username:  stat.Player.Username,
+				AvatarURL: stat.Player.AvatarURL,
+			},
+			Changes: diffPlayerStat(stat, nil),
+		})
+	}
+
+	return &dto.RecomputeStatsResponse{
+		DryRun:         dryRun,
+		ScoringVersion: rule.Version,
+		TotalPlayers:   len(after),
+		ChangedPlayers: len(diffs),
+		Diffs:          diffs,
+	}
+}
+
+func (ss *statService) GetLeaderboard(ctx context.Context, req *dto.LeaderboardRequest) (*dto.LeaderboardResponse, error) {
+	sortBy := req.Sort
+	if sortBy == "" {
+		sortBy = "score"
+	}
+	column, ok := leaderboardColumns[sortBy]
+	if !ok {
+		return &dto.LeaderboardResponse{}, fmt.Errorf("Failed unknown leaderboard sort %s: %w", sortBy, dto.ErrValidationFailed)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultLeaderboardLimit
+	}
+
+	stats, err := ss.playerStatRepo.GetLeaderboard(ctx, nil, column, limit)
+	if err != nil {
+		return &dto.LeaderboardResponse{}, fmt.Errorf("Failed to get leaderboard: %w", err)
+	}
+
+	entries := make([]*dto.LeaderboardEntryResponse, 0, len(stats))
+	for i, stat := range stats {
+		entries = append(entries, &dto.LeaderboardEntryResponse{
+			Rank:               i + 1,
+			PlayerStatResponse: toPlayerStatResponse(stat),
+		})
+	}
+
+	return &dto.LeaderboardResponse{
+		Sort:    sortBy,
+		Entries: entries,
+	}, nil
+}
+
+// GetHeadToHead compares two players over the matches they both played. As
+// with ratings, claims are grouped into matches by Claim.MatchKey (the match
+// date plus the screenshot), since a claim doesn't record who else was at the
+// table.
+func (ss *statService) GetHeadToHead(ctx context.Context, req *dto.HeadToHeadRequest) (*dto.HeadToHeadResponse, error) {
+	if req.PlayerAID == req.PlayerBID {
+		return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed players must be different: %w", dto.ErrValidationFailed)
+	}
+
+	playerAID, err := uuid.Parse(req.PlayerAID)
+	if err != nil {
+		return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed parse id from string to uuid: %w", dto.ErrValidationFailed)
+	}
+	playerBID, err := uuid.Parse(req.PlayerBID)
+	if err != nil {
+		return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed parse id from string to uuid: %w", dto.ErrValidationFailed)
+	}
+
+	sides := make(map[uuid.UUID]*dto.HeadToHeadSideResponse, 2)
+	for _, playerID := range []uuid.UUID{playerAID, playerBID} {
+		player, found, err := ss.userRepo.GetDetailByID(ctx, nil, &playerID)
+		if err != nil {
+			return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed to get user by id: %w", err)
+		}
+		if !found {
+			return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed user %s not found: %w", playerID, dto.ErrNotFound)
+		}
+
+		sides[playerID] = &dto.HeadToHeadSideResponse{
+			Player: dto.UserSimpleResponse{
+				ID:        player.ID,
+				Username:  player.Username,
+				AvatarURL: player.AvatarURL,
+			},
+		}
+	}
+
+	claims, err := ss.claimRepo.GetAllFinalApprovedSharedByPlayerIDs(ctx, nil, &playerAID, &playerBID)
+	if err != nil {
+		return &dto.HeadToHeadResponse{}, fmt.Errorf("Failed to get shared claims: %w", err)
+	}
+
+	// Claims arrive newest match first, so recent matches fill in order.
+	recent := make([]dto.HeadToHeadMatchResponse, 0, headToHeadRecentLimit)
+	matchIndex := map[string]int{}
+	for _, claim := range claims {
+		side := sides[claim.ClaimedPlayerID]
+		switch claim.Event {
+		case entity.EventKing:
+			side.KingCount++
+		case entity.EventKong:
+			side.KongCount++
+		case entity.EventNgok:
+			side.NgokCount++
+		}
+
+		i, ok := matchIndex[claim.MatchKey()]
+		if !ok {
+			i = len(matchIndex)
+			matchIndex[claim.MatchKey()] = i
+			if i < headToHeadRecentLimit {
+				recent = append(recent, dto.HeadToHeadMatchResponse{
+					MatchDate: claim.MatchDate.Format("2006-01-02"),
+					PlayerA:   []entity.ClaimEvent{},
+					PlayerB:   []entity.ClaimEvent{},
+				})
+			}
+		}
+		if i >= headToHeadRecentLimit {
+			continue
+		}
+
+		if claim.ClaimedPlayerID == playerAID {
+			recent[i].PlayerA = append(recent[i].PlayerA, claim.Event)
+		} else {
+			recent[i].PlayerB = append(recent[i].PlayerB, claim.Event)
+		}
+	}
+
+	playerA, playerB := sides[playerAID], sides[playerBID]
+	res := &dto.HeadToHeadResponse{
+		SharedMatches: len(matchIndex),
+		PlayerA:       *playerA,
+		PlayerB:       *playerB,
+		Recent:        recent,
+	}
+	if playerA.KingCount > playerB.KingCount {
+		res.MoreKings = &playerAID
+	} else if playerB.KingCount > playerA.KingCount {
+		res.MoreKings = &playerBID
+	}
+	if playerA.NgokCount > playerB.NgokCount {
+		res.MoreNgoks = &playerAID
+	} else if playerB.NgokCount > playerA.NgokCount {
+		res.MoreNgoks = &playerBID
+	}
+
+	return res, nil
+}
+
+// GetVoterStats lists every voter's record, most reputable first.
+func (ss *statService) GetVoterStats(ctx context.Context) ([]*dto.VoterStatResponse, error) {
+	stats, err := ss.voterStatRepo.GetAll(ctx, nil)
+	if err != nil {
+		return []*dto.VoterStatResponse{}, fmt.Errorf("Failed to get voter stats: %w", err)
+	}
+
+	res := make([]*dto.VoterStatResponse, 0, len(stats))
+	for _, stat := range stats {
+		res = append(res, toVoterStatResponse(stat))
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Reputation > res[j].Reputation
+	})
+
+	return res, nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/logger"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -29,8 +30,10 @@ type (
 	}
 )
 
-func NewUploadService() *uploadService {
-	return &uploadService{}
+func NewUploadService(logger *zap.Logger) *uploadService {
+	return &uploadService{
+		logger: logger,
+	}
 }
 
 var allowedExt = map[string]bool{
@@ -45,14 +48,15 @@ var allowedExt = map[string]bool{
 }
 
 func (us *uploadService) Upload(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	log := logger.FromContext(ctx, us.logger)
 	if file == nil {
-		us.logger.Warn("Upload attempted with no file")
+		log.Warn("Upload attempted with no file")
 		return "", dto.ErrNoFilesUploaded
 	}
 
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 	if !allowedExt[ext] {
-		us.logger.Warn("Invalid file type",
+		log.Warn("Invalid file type",
 			zap.String("filename", file.Filename),
 			zap.String("extension", ext),
 		)
@@ -63,7 +67,7 @@ func (us *uploadService) Upload(ctx context.Context, file *multipart.FileHeader)
 	storagePath := filepath.Join("uploads", newFileName)
 
 	if err := us.saveUploadedFile(file, storagePath); err != nil {
-		us.logger.Error("Failed to save uploaded file",
+		log.Error("Failed to save uploaded file",
 			zap.String("filename", file.Filename),
 			zap.String("path", storagePath),
 			zap.Error(err),
@@ -71,6 +75,10 @@ func (us *uploadService) Upload(ctx context.Context, file *multipart.FileHeader)
 		return "", dto.ErrSaveFile
 	}
 
+	log.Info("File uploaded",
+		zap.String("filename", file.Filename),
+		zap.String("path", storagePath),
+	)
 	return storagePath, nil
 }
 
@@ -3,49 +3,103 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Amierza/mc-kalak-backend/dto"
+	"github.com/Amierza/mc-kalak-backend/entity"
 	"github.com/Amierza/mc-kalak-backend/jwt"
+	"github.com/Amierza/mc-kalak-backend/logger"
 	"github.com/Amierza/mc-kalak-backend/repository"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 type (
 	IUserService interface {
 		GetProfile(ctx context.Context) (*dto.UserResponse, error)
 		Update(ctx context.Context, req *dto.UpdateProfileRequest) (*dto.UserResponse, error)
+		GetRatingHistory(ctx context.Context, id *uuid.UUID) (*dto.RatingHistoryResponse, error)
+		GetBadges(ctx context.Context, id *uuid.UUID) ([]dto.BadgeResponse, error)
+		GetProfileStats(ctx context.Context) (*dto.ProfileStatsResponse, error)
 	}
 
 	userService struct {
-		userRepo repository.IUserRepository
-		jwt      jwt.IJWT
+		userRepo          repository.IUserRepository
+		ratingHistoryRepo repository.IRatingHistoryRepository
+		achievementRepo   repository.IAchievementRepository
+		playerStatRepo    repository.IPlayerStatRepository
+		userStatRepo      repository.IUserStatRepository
+		jwt               jwt.IJWT
+		logger            *zap.Logger
 	}
 )
 
-func NewUserService(userRepo repository.IUserRepository, jwt jwt.IJWT) *userService {
+func NewUserService(userRepo repository.IUserRepository, ratingHistoryRepo repository.IRatingHistoryRepository, achievementRepo repository.IAchievementRepository, playerStatRepo repository.IPlayerStatRepository, userStatRepo repository.IUserStatRepository, jwt jwt.IJWT, logger *zap.Logger) *userService {
 	return &userService{
-		userRepo: userRepo,
-		jwt:      jwt,
+		userRepo:          userRepo,
+		ratingHistoryRepo: ratingHistoryRepo,
+		achievementRepo:   achievementRepo,
+		playerStatRepo:    playerStatRepo,
+		userStatRepo:      userStatRepo,
+		jwt:               jwt,
+		logger:            logger,
 	}
 }
 
+func (us *userService) getBadgesByUserID(ctx context.Context, userID *uuid.UUID) ([]dto.BadgeResponse, error) {
+	userAchievements, err := us.achievementRepo.GetAllByUserID(ctx, nil, userID)
+	if err != nil {
+		return []dto.BadgeResponse{}, fmt.Errorf("Failed to get achievements by user id: %w", err)
+	}
+
+	badges := make([]dto.BadgeResponse, 0, len(userAchievements))
+	for _, userAchievement := range userAchievements {
+		badges = append(badges, dto.BadgeResponse{
+			Code:        userAchievement.Achievement.Code,
+			Name:        userAchievement.Achievement.Name,
+			Description: userAchievement.Achievement.Description,
+			Icon:        userAchievement.Achievement.Icon,
+			ClaimID:     userAchievement.ClaimID,
+			AwardedAt:   userAchievement.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return badges, nil
+}
+
 func (us *userService) GetProfile(ctx context.Context) (*dto.UserResponse, error) {
 	token := ctx.Value("Authorization").(string)
 	userIDString, err := us.jwt.GetUserIDByToken(token)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by token: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by token: %w", err)
 	}
 	userID, err := uuid.Parse(userIDString)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to parse uuid into string: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to parse uuid into string: %w", err)
 	}
 
 	data, found, err := us.userRepo.GetDetailByID(ctx, nil, &userID)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by id: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by id: %w", err)
 	}
 	if !found {
-		return &dto.UserResponse{}, fmt.Errorf("Failed user not found: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed user not found: %w", dto.ErrNotFound)
+	}
+
+	badges, err := us.getBadgesByUserID(ctx, &userID)
+	if err != nil {
+		return &dto.UserResponse{}, err
+	}
+
+	var stats *dto.PlayerStatResponse
+	stat, found, err := us.playerStatRepo.GetByPlayerID(ctx, nil, &userID)
+	if err != nil {
+		return &dto.UserResponse{}, fmt.Errorf("Failed to get player stat by player id: %w", err)
+	}
+	if found {
+		stat.Player = *data
+		res := toPlayerStatResponse(stat)
+		stats = &res
 	}
 
 	user := &dto.UserResponse{
@@ -54,6 +108,9 @@ func (us *userService) GetProfile(ctx context.Context) (*dto.UserResponse, error
 		Password:  data.Password,
 		AvatarURL: data.AvatarURL,
 		IsActive:  data.IsActive,
+		Role:      data.Role,
+		Badges:    badges,
+		Stats:     stats,
 		TimestampTemplate: dto.TimestampTemplate{
 			CreatedAt: data.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: data.UpdatedAt.Format("2006-01-02 15:04:05"),
@@ -67,33 +124,36 @@ func (us *userService) Update(ctx context.Context, req *dto.UpdateProfileRequest
 	token := ctx.Value("Authorization").(string)
 	userIDString, err := us.jwt.GetUserIDByToken(token)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to get user ID by token: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to get user ID by token: %w", err)
 	}
 	userID, err := uuid.Parse(userIDString)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed parse id from string to uuid: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed parse id from string to uuid: %w", err)
 	}
 
 	user, found, err := us.userRepo.GetDetailByID(ctx, nil, &userID)
 	if err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by id: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to get user by id: %w", err)
 	}
 	if !found {
-		return &dto.UserResponse{}, fmt.Errorf("Failed user not found: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed user not found: %w", dto.ErrNotFound)
 	}
 
 	user.AvatarURL = req.AvatarURL
 
 	if err := us.userRepo.Update(ctx, nil, user); err != nil {
-		return &dto.UserResponse{}, fmt.Errorf("Failed to update user: %v\n", err)
+		return &dto.UserResponse{}, fmt.Errorf("Failed to update user: %w", err)
 	}
 
+	logger.FromContext(ctx, us.logger).Info("profile updated")
+
 	res := &dto.UserResponse{
 		ID:        user.ID,
 		Username:  user.Username,
 		Password:  user.Password,
 		AvatarURL: user.AvatarURL,
 		IsActive:  user.IsActive,
+		Role:      user.Role,
 		TimestampTemplate: dto.TimestampTemplate{
 			CreatedAt: user.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: user.UpdatedAt.Format("2006-01-02 15:04:05"),
@@ -102,3 +162,114 @@ func (us *userService) Update(ctx context.Context, req *dto.UpdateProfileRequest
 
 	return res, nil
 }
+
+func (us *userService) GetRatingHistory(ctx context.Context, id *uuid.UUID) (*dto.RatingHistoryResponse, error) {
+	user, found, err := us.userRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return &dto.RatingHistoryResponse{}, fmt.Errorf("Failed to get user by id: %w", err)
+	}
+	if !found {
+		return &dto.RatingHistoryResponse{}, fmt.Errorf("Failed user not found: %w", dto.ErrNotFound)
+	}
+
+	histories, err := us.ratingHistoryRepo.GetAllByPlayerID(ctx, nil, id)
+	if err != nil {
+		return &dto.RatingHistoryResponse{}, fmt.Errorf("Failed to get rating history: %w", err)
+	}
+
+	res := &dto.RatingHistoryResponse{
+		Player: dto.UserSimpleResponse{
+			ID:        user.ID,
+			Username:  user.Username,
+			AvatarURL: user.AvatarURL,
+		},
+		CurrentRating: entity.InitialRating,
+		History:       make([]dto.RatingHistoryEntryResponse, 0, len(histories)),
+	}
+	for _, history := range histories {
+		res.CurrentRating = history.RatingAfter
+		res.History = append(res.History, dto.RatingHistoryEntryResponse{
+			MatchDate:    history.MatchDate.Format("2006-01-02"),
+			RatingBefore: history.RatingBefore,
+			RatingAfter:  history.RatingAfter,
+			Delta:        history.Delta,
+			Opponents:    history.Opponents,
+		})
+	}
+
+	return res, nil
+}
+
+func (us *userService) GetBadges(ctx context.Context, id *uuid.UUID) ([]dto.BadgeResponse, error) {
+	_, found, err := us.userRepo.GetDetailByID(ctx, nil, id)
+	if err != nil {
+		return []dto.BadgeResponse{}, fmt.Errorf("Failed to get user by id: %w", err)
+	}
+	if !found {
+		return []dto.BadgeResponse{}, fmt.Errorf("Failed user not found: %w", dto.ErrNotFound)
+	}
+
+	return us.getBadgesByUserID(ctx, id)
+}
+
+// GetProfileStats builds the caller's dashboard. Every figure is aggregated by
+// the database; only the per-month and per-weekday rows come back.
+func (us *userService) GetProfileStats(ctx context.Context) (*dto.ProfileStatsResponse, error) {
+	userID, err := getUserIDFromContext(ctx)
+	if err != nil {
+		return &dto.ProfileStatsResponse{}, err
+	}
+
+	monthly, err := us.userStatRepo.GetMonthlyByPlayerID(ctx, nil, &userID)
+	if err != nil {
+		return &dto.ProfileStatsResponse{}, fmt.Errorf("Failed to get monthly stats: %w", err)
+	}
+
+	weekdays, err := us.userStatRepo.GetWeekdayByPlayerID(ctx, nil, &userID)
+	if err != nil {
+		return &dto.ProfileStatsResponse{}, fmt.Errorf("Failed to get weekday stats: %w", err)
+	}
+
+	reported, err := us.userStatRepo.GetReportedByReporterID(ctx, nil, &userID)
+	if err != nil {
+		return &dto.ProfileStatsResponse{}, fmt.Errorf("Failed to get reported claim stats: %w", err)
+	}
+
+	votes, err := us.userStatRepo.GetVotesByVoterID(ctx, nil, &userID)
+	if err != nil {
+		return &dto.ProfileStatsResponse{}, fmt.Errorf("Failed to get vote stats: %w", err)
+	}
+
+	res := &dto.ProfileStatsResponse{
+		Monthly:        monthly,
+		Weekdays:       weekdays,
+		ReportedClaims: reported,
+		Votes:          votes,
+	}
+	if res.Monthly == nil {
+		res.Monthly = []dto.MonthlyStatResponse{}
+	}
+	if res.Weekdays == nil {
+		res.Weekdays = []dto.WeekdayStatResponse{}
+	}
+
+	// Best and worst days are by win rate, ties going to the busier day.
+	var best, worst *dto.WeekdayStatResponse
+	for i := range res.Weekdays {
+		day := &res.Weekdays[i]
+		day.Day = time.Weekday(day.DayOfWeek % 7).String()
+
+		if best == nil || day.WinRate > best.WinRate || (day.WinRate == best.WinRate && day.TotalMatch > best.TotalMatch) {
+			best = day
+		}
+		if worst == nil || day.WinRate < worst.WinRate || (day.WinRate == worst.WinRate && day.TotalMatch > worst.TotalMatch) {
+			worst = day
+		}
+	}
+	if best != nil {
+		res.BestDay = &best.Day
+		res.WorstDay = &worst.Day
+	}
+
+	return res, nil
+}